@@ -0,0 +1,23 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+
+	pipe "github.com/renatopp/pipelang"
+)
+
+func Check() {
+	failed := false
+	for _, file := range os.Args[2:] {
+		err := pipe.CheckFile(file)
+		if err != nil {
+			fmt.Println(err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,7 @@
 package cmds
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,10 +9,21 @@ import (
 )
 
 func Run() {
-	file := os.Args[2]
-	_, err := pipe.RunFile(file)
+	var opts pipe.Options
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	flags.BoolVar(&opts.TracePipes, "trace-pipes", false, "print the elements and time of every pipe stage")
+	flags.Parse(os.Args[2:])
+	if flags.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "usage: pipe run [--trace-pipes] [file] [args]")
+		os.Exit(1)
+	}
+
+	file := flags.Arg(0)
+	opts.Args = flags.Args()[1:]
+	_, err := pipe.RunFileWithOptions(file, opts)
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
 	// fmt.Println(res)
@@ -45,6 +45,9 @@ func main() {
 	case "eval":
 		eval()
 
+	case "check":
+		check()
+
 	case "shell":
 		shell()
 
@@ -67,8 +70,10 @@ func help() {
 	fmt.Println("  version        Print the version")
 	fmt.Println("  help           Print this help")
 	fmt.Println("  shell          Start the REPL")
-	fmt.Println("  run [file]     Run a file")
+	fmt.Println("  run [file]     Run a file, passing the next args to the script")
+	fmt.Println("                 --trace-pipes prints the metrics of each pipe stage")
 	fmt.Println("  eval [command] Evaluate a string")
+	fmt.Println("  check [files]  Type check files")
 	fmt.Println("  -              Read from stdin")
 }
 
@@ -84,6 +89,10 @@ func eval() {
 	cmds.Eval()
 }
 
+func check() {
+	cmds.Check()
+}
+
 func debug() {
 	cmds.Debug()
 }
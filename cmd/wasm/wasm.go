@@ -4,6 +4,7 @@ package main
 
 import (
 	"syscall/js"
+	_ "time/tzdata" // the browser has no zoneinfo database for Time.In
 
 	"github.com/renatopp/pipelang/internal/ast"
 	"github.com/renatopp/pipelang/internal/object"
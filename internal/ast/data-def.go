@@ -19,6 +19,9 @@ type DataDef struct {
 	Extensions []Node
 	Attributes map[string]Node
 	Methods    map[string]Node
+
+	// Optional type annotations of the attributes, like `a: Number = 0`
+	AttributeTypes map[string]Node
 }
 
 func (n *DataDef) GetToken() *tokens.Token {
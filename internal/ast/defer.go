@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"encoding/gob"
+
+	"github.com/renatopp/langtools/tokens"
+)
+
+func init() {
+	gob.Register(&Defer{})
+}
+
+// Defer holds an expression evaluated when the enclosing function finishes.
+type Defer struct {
+	*InternalNode
+	Token      *tokens.Token
+	Expression Node
+}
+
+func (n *Defer) GetToken() *tokens.Token {
+	return n.Token
+}
+
+func (n *Defer) String() string {
+	return "<defer>"
+}
+
+func (n *Defer) Children() []Node {
+	return []Node{n.Expression}
+}
+
+func (n *Defer) Walk(fn WalkFn) {
+	n.Expression = fn(n.Expression)
+
+	for _, child := range n.Children() {
+		child.Walk(fn)
+	}
+}
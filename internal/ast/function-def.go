@@ -20,6 +20,7 @@ type FunctionDef struct {
 	Parameters []Node
 	Body       Node
 	Generator  bool
+	ReturnType Node // Optional type annotation, like `fn f(): Number {}`
 }
 
 func (n *FunctionDef) GetToken() *tokens.Token {
@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"encoding/gob"
+
+	"github.com/renatopp/langtools/tokens"
+)
+
+func init() {
+	gob.Register(&Go{})
+}
+
+// Go holds an expression evaluated concurrently, in another goroutine.
+type Go struct {
+	*InternalNode
+	Token      *tokens.Token
+	Expression Node
+}
+
+func (n *Go) GetToken() *tokens.Token {
+	return n.Token
+}
+
+func (n *Go) String() string {
+	return "<go>"
+}
+
+func (n *Go) Children() []Node {
+	return []Node{n.Expression}
+}
+
+func (n *Go) Walk(fn WalkFn) {
+	n.Expression = fn(n.Expression)
+
+	for _, child := range n.Children() {
+		child.Walk(fn)
+	}
+}
@@ -15,6 +15,7 @@ type Identifier struct {
 	*InternalNode
 	Token *tokens.Token
 	Value string
+	Type  Node // Optional type annotation, like `a: Number`
 }
 
 func (n *Identifier) GetToken() *tokens.Token {
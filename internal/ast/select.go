@@ -0,0 +1,42 @@
+package ast
+
+import (
+	"encoding/gob"
+
+	"github.com/renatopp/langtools/tokens"
+)
+
+func init() {
+	gob.Register(&Select{})
+}
+
+// Select waits for the first ready channel operation, like
+// `select { ch.Receive() as x: ..., out.Send(v): ..., _: ... }`.
+type Select struct {
+	*InternalNode
+	Token *tokens.Token
+	Cases []Node   // [operation, expression, operation, expression, ...]
+	Names []string // variable receiving the result of each operation, or empty
+}
+
+func (n *Select) GetToken() *tokens.Token {
+	return n.Token
+}
+
+func (n *Select) String() string {
+	return "<select>"
+}
+
+func (n *Select) Children() []Node {
+	return n.Cases
+}
+
+func (n *Select) Walk(fn WalkFn) {
+	for i := range n.Cases {
+		n.Cases[i] = fn(n.Cases[i])
+	}
+
+	for _, child := range n.Children() {
+		child.Walk(fn)
+	}
+}
@@ -0,0 +1,55 @@
+package ast
+
+import (
+	"encoding/gob"
+
+	"github.com/renatopp/langtools/tokens"
+)
+
+func init() {
+	gob.Register(&Slice{})
+}
+
+// Represents the slice inside an index, like `a[1:3]`, `a[:-1]` or `a[::2]`.
+// Omitted parts are nil.
+type Slice struct {
+	*InternalNode
+	Token *tokens.Token
+	Start Node
+	Stop  Node
+	Step  Node
+}
+
+func (n *Slice) GetToken() *tokens.Token {
+	return n.Token
+}
+
+func (n *Slice) String() string {
+	return "<slice>"
+}
+
+func (n *Slice) Children() []Node {
+	children := []Node{}
+	for _, child := range []Node{n.Start, n.Stop, n.Step} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (n *Slice) Walk(fn WalkFn) {
+	if n.Start != nil {
+		n.Start = fn(n.Start)
+	}
+	if n.Stop != nil {
+		n.Stop = fn(n.Stop)
+	}
+	if n.Step != nil {
+		n.Step = fn(n.Step)
+	}
+
+	for _, child := range n.Children() {
+		child.Walk(fn)
+	}
+}
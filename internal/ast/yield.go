@@ -15,6 +15,7 @@ type Yield struct {
 	Token      *tokens.Token
 	Expression Node
 	Break      bool
+	Delegate   bool // `yield from <expr>`
 }
 
 func (n *Yield) GetToken() *tokens.Token {
@@ -26,6 +27,10 @@ func (n *Yield) String() string {
 		return "<yield:break>"
 	}
 
+	if n.Delegate {
+		return "<yield:from>"
+	}
+
 	return "<yield>"
 }
 
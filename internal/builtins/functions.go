@@ -22,6 +22,72 @@ func RegisterBuiltinFunctions(s *o.Scope) {
 	setFunction(s, o.SumBy)
 	setFunction(s, o.Count)
 	setFunction(s, o.CountBy)
+	setFunction(s, o.Take)
+	setFunction(s, o.Skip)
+	setFunction(s, o.TakeWhile)
+	setFunction(s, o.DropWhile)
+	setFunction(s, o.First)
+	setFunction(s, o.Last)
+	setFunction(s, o.Nth)
+	setFunction(s, o.Find)
+	setFunction(s, o.Any)
+	setFunction(s, o.All)
+	setFunction(s, o.Zip)
+	setFunction(s, o.ZipLongest)
+	setFunction(s, o.Enumerate)
+	setFunction(s, o.Chain)
+	setFunction(s, o.Concat)
+	setFunction(s, o.Interleave)
+	setFunction(s, o.Product)
+	setFunction(s, o.FlatMap)
+	setFunction(s, o.Flatten)
+	setFunction(s, o.Recurse)
+	setFunction(s, o.Chunk)
+	setFunction(s, o.Window)
+	setFunction(s, o.GroupBy)
+	setFunction(s, o.GroupRuns)
+	setFunction(s, o.Partition)
+	setFunction(s, o.Scan)
+	setFunction(s, o.Fold)
+	setFunction(s, o.Distinct)
+	setFunction(s, o.DistinctBy)
+	setFunction(s, o.Min)
+	setFunction(s, o.Max)
+	setFunction(s, o.MinBy)
+	setFunction(s, o.MaxBy)
+	setFunction(s, o.SortBy)
+	setFunction(s, o.Join)
+	setFunction(s, o.LeftJoin)
+	setFunction(s, o.OuterJoin)
+	setFunction(s, o.OrderBy)
+	setFunction(s, o.Project)
+	setFunction(s, o.Rename)
+	setFunction(s, o.Aggregate)
+	setFunction(s, o.Pivot)
+	setFunction(s, o.Sleep)
+	setFunction(s, o.After)
+	setFunction(s, o.Interval)
+	setFunction(s, o.Ticker)
+	setFunction(s, o.Throttle)
+	setFunction(s, o.Debounce)
+	setFunction(s, o.BufferTime)
+	setFunction(s, o.Sample)
+	setFunction(s, o.Delay)
+	setFunction(s, o.Timeout)
+	setFunction(s, o.PMap)
+	setFunction(s, o.PFilter)
+	setFunction(s, o.PEach)
+	setFunction(s, o.WaitAll)
+	setFunction(s, o.WaitAny)
+	setFunction(s, o.Tee)
+	setFunction(s, o.Fork)
+	setFunction(s, o.Broadcast)
+	setFunction(s, o.Tap)
+	setFunction(s, o.Progress)
+	setFunction(s, o.WriteLines)
+	setFunction(s, o.Sh)
+	setFunction(s, o.Exec)
+	setFunction(s, o.Hash)
 	setFunction(s, Import)
 }
 
@@ -4,6 +4,15 @@ import o "github.com/renatopp/pipelang/internal/object"
 
 func RegisterBuiltinModules(s *o.Scope) {
 	addModule(s, o.Module_Math)
+	addModule(s, o.Module_File)
+	addModule(s, o.Module_Path)
+	addModule(s, o.Module_Os)
+	addModule(s, o.Module_Exec)
+	addModule(s, o.Module_Json)
+	addModule(s, o.Module_Csv)
+	addModule(s, o.Module_Yaml)
+	addModule(s, o.Module_Xml)
+	addModule(s, o.Module_Regex)
 }
 
 func addModule(s *o.Scope, module *o.ModuleType) {
@@ -14,7 +14,16 @@ func RegisterBuiltinTypes(s *o.Scope) {
 	s.SetLocal("Function", o.FunctionTypeObj)
 	s.SetLocal("Tuple", o.TupleTypeObj)
 	s.SetLocal("List", o.ListTypeObj)
+	s.SetLocal("Dict", o.DictTypeObj)
 	s.SetLocal("Maybe", o.MaybeTypeObj)
 	s.SetLocal("Error", o.ErrorTypeObj)
-	s.SetLocal("Stream", o.ErrorTypeObj)
+	s.SetLocal("Stream", o.StreamTypeObj)
+	s.SetLocal("Task", o.TaskTypeObj)
+	s.SetLocal("Channel", o.ChannelTypeObj)
+	s.SetLocal("Process", o.ProcessTypeObj)
+	s.SetLocal("XmlElement", o.XmlElementTypeObj)
+	s.SetLocal("Pattern", o.PatternTypeObj)
+	s.SetLocal("PatternMatch", o.PatternMatchTypeObj)
+	s.SetLocal("Time", o.TimeTypeObj)
+	s.SetLocal("Duration", o.DurationTypeObj)
 }
@@ -0,0 +1,734 @@
+package checker
+
+import (
+	"fmt"
+
+	"github.com/renatopp/pipelang/internal"
+	"github.com/renatopp/pipelang/internal/ast"
+)
+
+// Unknown is used when the type of an expression cannot be inferred. The
+// checker never reports errors involving unknown types.
+const Unknown = ""
+
+// The builtin types that can be used in annotations.
+var builtinTypes = map[string]bool{
+	"Type":         true,
+	"Number":       true,
+	"String":       true,
+	"Boolean":      true,
+	"Function":     true,
+	"Tuple":        true,
+	"List":         true,
+	"Dict":         true,
+	"Maybe":        true,
+	"Error":        true,
+	"Stream":       true,
+	"Task":         true,
+	"Channel":      true,
+	"Process":      true,
+	"XmlElement":   true,
+	"Pattern":      true,
+	"PatternMatch": true,
+	"Time":         true,
+	"Duration":     true,
+}
+
+// The return types of the builtin functions. Every registered builtin must be
+// listed, with Unknown when the type depends on the arguments.
+var builtinReturns = map[string]string{
+	"printf":     "Tuple",
+	"printfln":   "Tuple",
+	"print":      "Tuple",
+	"println":    "Tuple",
+	"sprintf":    "String",
+	"sprintfln":  "String",
+	"sprint":     "String",
+	"sprintln":   "String",
+	"range":      "Stream",
+	"filter":     "Stream",
+	"each":       "Stream",
+	"map":        "Stream",
+	"reduce":     Unknown,
+	"fold":       Unknown,
+	"sum":        "Number",
+	"sumBy":      "Number",
+	"count":      "Number",
+	"countBy":    "Number",
+	"hash":       "Number",
+	"take":       "Stream",
+	"skip":       "Stream",
+	"takeWhile":  "Stream",
+	"dropWhile":  "Stream",
+	"first":      "Maybe",
+	"last":       "Maybe",
+	"nth":        "Maybe",
+	"find":       "Maybe",
+	"any":        "Boolean",
+	"all":        "Boolean",
+	"zip":        "Stream",
+	"zipLongest": "Stream",
+	"enumerate":  "Stream",
+	"chain":      "Stream",
+	"concat":     "Stream",
+	"interleave": "Stream",
+	"product":    "Stream",
+	"flatMap":    "Stream",
+	"flatten":    "Stream",
+	"recurse":    "Stream",
+	"chunk":      "Stream",
+	"window":     "Stream",
+	"groupBy":    "Dict",
+	"groupRuns":  "Stream",
+	"partition":  "Tuple",
+	"scan":       "Stream",
+	"distinct":   "Stream",
+	"distinctBy": "Stream",
+	"min":        "Maybe",
+	"max":        "Maybe",
+	"minBy":      "Maybe",
+	"maxBy":      "Maybe",
+	"sortBy":     "List",
+	"join":       "Stream",
+	"leftJoin":   "Stream",
+	"outerJoin":  "Stream",
+	"orderBy":    "List",
+	"project":    "Stream",
+	"rename":     "Stream",
+	"aggregate":  "Dict",
+	"pivot":      "Dict",
+	"sleep":      "Boolean",
+	"after":      "Channel",
+	"interval":   "Stream",
+	"ticker":     "Stream",
+	"throttle":   "Stream",
+	"debounce":   "Stream",
+	"bufferTime": "Stream",
+	"sample":     "Stream",
+	"delay":      "Stream",
+	"timeout":    "Stream",
+	"pmap":       "Stream",
+	"pfilter":    "Stream",
+	"peach":      "Stream",
+	"waitAll":    "List",
+	"waitAny":    "Tuple",
+	"tee":        "Tuple",
+	"fork":       "Tuple",
+	"broadcast":  "Tuple",
+	"tap":        "Stream",
+	"progress":   "Stream",
+	"writeLines": "Number",
+	"sh":         "Process",
+	"exec":       "Stream",
+	"import":     Unknown,
+}
+
+type function struct {
+	name       string
+	params     []string // type of each parameter, Unknown if not annotated
+	names      []string
+	spread     bool // if the last parameter is a spread-in `...a`
+	returnType string
+	generator  bool
+}
+
+type data struct {
+	name       string
+	attributes map[string]string // annotated type of each attribute
+	extends    []*data
+}
+
+func (d *data) is(other *data) bool {
+	if d == other {
+		return true
+	}
+	for _, ext := range d.extends {
+		if ext.is(other) {
+			return true
+		}
+	}
+	return false
+}
+
+type symbol struct {
+	tp       string
+	function *function
+	data     *data
+}
+
+type scope struct {
+	parent  *scope
+	symbols map[string]*symbol
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		parent:  parent,
+		symbols: map[string]*symbol{},
+	}
+}
+
+func (s *scope) get(name string) *symbol {
+	for cur := s; cur != nil; cur = cur.parent {
+		if sym, ok := cur.symbols[name]; ok {
+			return sym
+		}
+	}
+	return nil
+}
+
+func (s *scope) set(name string, sym *symbol) {
+	s.symbols[name] = sym
+}
+
+// Checker infers the types of the expressions of a program and reports the
+// mismatches with its type annotations, without running it.
+type Checker struct {
+	errors  []*internal.Error
+	returns []string // stack of the return types of the functions being checked
+}
+
+// Check runs the type checker over the program, returning the errors found.
+func Check(root ast.Node) []*internal.Error {
+	c := &Checker{}
+	c.check(newScope(nil), root)
+	return c.errors
+}
+
+func (c *Checker) report(node ast.Node, format string, args ...any) {
+	c.errors = append(c.errors, &internal.Error{
+		Message: fmt.Sprintf(format, args...),
+		Node:    node,
+	})
+}
+
+// Resolves a type annotation into a type name, or Unknown if the type is not
+// known by the checker, like a type imported from another module.
+func (c *Checker) resolveType(s *scope, node ast.Node) string {
+	ident, ok := node.(*ast.Identifier)
+	if !ok {
+		return Unknown
+	}
+
+	if sym := s.get(ident.Value); sym != nil {
+		if sym.data != nil {
+			return sym.data.name
+		}
+		return Unknown
+	}
+
+	if builtinTypes[ident.Value] {
+		return ident.Value
+	}
+	return Unknown
+}
+
+// Returns true if a value of type `actual` can be used where `expected` is
+// required.
+func (c *Checker) compatible(s *scope, expected, actual string) bool {
+	if expected == Unknown || actual == Unknown || expected == actual {
+		return true
+	}
+
+	a := s.get(actual)
+	e := s.get(expected)
+	if a != nil && e != nil && a.data != nil && e.data != nil {
+		return a.data.is(e.data)
+	}
+	return false
+}
+
+func (c *Checker) check(s *scope, node ast.Node) string {
+	if node == nil {
+		return Unknown
+	}
+
+	switch n := node.(type) {
+	case *ast.Number:
+		return "Number"
+
+	case *ast.String:
+		return "String"
+
+	case *ast.Boolean:
+		return "Boolean"
+
+	case *ast.Identifier:
+		sym := s.get(n.Value)
+		switch {
+		case sym == nil:
+			return Unknown
+		case sym.function != nil:
+			return "Function"
+		case sym.data != nil:
+			return Unknown
+		}
+		return sym.tp
+
+	case *ast.Tuple:
+		c.checkAll(s, n.Elements)
+		return "Tuple"
+
+	case *ast.List:
+		c.checkAll(s, n.Elements)
+		return "List"
+
+	case *ast.Dict:
+		c.checkAll(s, n.Elements)
+		return "Dict"
+
+	case *ast.Block:
+		inner := s
+		if n.Scoped {
+			inner = newScope(s)
+		}
+
+		tp := Unknown
+		for _, expr := range n.Expressions {
+			tp = c.check(inner, expr)
+		}
+		return tp
+
+	case *ast.FunctionDef:
+		c.checkFunctionDef(s, n, n.Name, n.Name != "")
+		return "Function"
+
+	case *ast.DataDef:
+		c.checkDataDef(s, n)
+		return Unknown
+
+	case *ast.Assignment:
+		return c.checkAssignment(s, n)
+
+	case *ast.InfixOperator:
+		return c.checkInfixOperator(s, n)
+
+	case *ast.PrefixOperator:
+		right := c.check(s, n.Right)
+		switch n.Operator {
+		case "-", "+":
+			return right
+		case "not", "!":
+			return "Boolean"
+		}
+		return Unknown
+
+	case *ast.Call:
+		return c.checkCall(s, n)
+
+	case *ast.Instantiate:
+		return c.checkInstantiate(s, n)
+
+	case *ast.Wrap:
+		c.check(s, n.Target)
+		return "Maybe"
+
+	case *ast.Go:
+		c.check(s, n.Expression)
+		return "Task"
+
+	case *ast.Return:
+		tp := c.check(s, n.Expression)
+		c.checkReturn(s, n.Expression, tp)
+		return tp
+
+	case *ast.Match:
+		c.check(s, n.Expression)
+		for i := 0; i < len(n.Cases); i += 2 {
+			inner := newScope(s)
+			c.checkPattern(inner, n.Cases[i])
+			c.check(inner, n.Cases[i+1])
+		}
+		return Unknown
+
+	case *ast.For:
+		inner := newScope(s)
+		c.checkAll(inner, n.Conditions)
+		c.check(inner, n.InExpression)
+		c.check(inner, n.Expression)
+		return Unknown
+
+	case *ast.With:
+		inner := newScope(s)
+		c.check(inner, n.Condition)
+		c.check(inner, n.Expression)
+		return Unknown
+	}
+
+	c.checkAll(s, node.Children())
+	return Unknown
+}
+
+func (c *Checker) checkAll(s *scope, nodes []ast.Node) {
+	for _, node := range nodes {
+		c.check(s, node)
+	}
+}
+
+// Match cases may declare variables with `as`, which are not checked.
+func (c *Checker) checkPattern(s *scope, node ast.Node) {
+	ast.Traverse(node, func(_ int, n ast.Node) {
+		if assignment, ok := n.(*ast.Assignment); ok {
+			c.declare(s, assignment.Left, Unknown)
+		}
+	})
+}
+
+// Declares the identifiers of the left side of an assignment.
+func (c *Checker) declare(s *scope, left ast.Node, tp string) {
+	switch left := left.(type) {
+	case *ast.Identifier:
+		s.set(left.Value, &symbol{tp: tp})
+	case *ast.Tuple:
+		for _, el := range left.Elements {
+			c.declare(s, el, Unknown)
+		}
+	case *ast.Spread:
+		c.declare(s, left.Target, "List")
+	}
+}
+
+func (c *Checker) checkAssignment(s *scope, n *ast.Assignment) string {
+	left := n.Left
+	tuple, isTuple := left.(*ast.Tuple)
+	if isTuple && len(tuple.Elements) == 1 {
+		left = tuple.Elements[0]
+	}
+	ident, ok := left.(*ast.Identifier)
+
+	// Function literals bound to a name are checked like named functions
+	var fn *function
+	right := "Function"
+	if def, isDef := n.Right.(*ast.FunctionDef); ok && isDef && def.Name == "" {
+		fn = c.checkFunctionDef(s, def, ident.Value, n.Operator == ":=")
+	} else {
+		right = c.check(s, n.Right)
+	}
+
+	if isTuple && len(tuple.Elements) != 1 {
+		// Destructuring assignments are not inferred
+		right = Unknown
+	}
+
+	if !ok {
+		c.check(s, left)
+		if n.Operator == ":=" {
+			c.declare(s, left, Unknown)
+		}
+		return right
+	}
+
+	tp := right
+	if ident.Type != nil {
+		tp = c.resolveType(s, ident.Type)
+		if !c.compatible(s, tp, right) {
+			c.report(n, "cannot assign '%s' to '%s' of type '%s'", right, ident.Value, tp)
+		}
+	}
+
+	if n.Operator == ":=" {
+		if fn != nil && tp == right {
+			s.set(ident.Value, &symbol{function: fn})
+		} else {
+			s.set(ident.Value, &symbol{tp: tp})
+		}
+		return right
+	}
+
+	// Reassigned functions take the signature of the new value
+	sym := s.get(ident.Value)
+	if sym != nil && sym.function != nil {
+		sym.function = fn
+		if fn == nil {
+			sym.tp = right
+		}
+		return right
+	}
+
+	// Reassignments must keep the type of the variable, except for maybes
+	if sym != nil && sym.data == nil && sym.tp != "Maybe" {
+		if !c.compatible(s, sym.tp, right) {
+			c.report(n, "cannot assign '%s' to '%s' of type '%s'", right, ident.Value, sym.tp)
+		}
+	}
+	return right
+}
+
+func (c *Checker) checkInfixOperator(s *scope, n *ast.InfixOperator) string {
+	left := c.check(s, n.Left)
+	right := c.check(s, n.Right)
+
+	switch n.Operator {
+	case "..":
+		return "String"
+
+	case "and", "or", "xor", "==", "!=":
+		return "Boolean"
+
+	case "??":
+		return Unknown
+	}
+
+	if left == Unknown || right == Unknown {
+		return Unknown
+	}
+
+	if ret, ok := timeOperator(left, n.Operator, right); ok {
+		return ret
+	}
+
+	if left != right && s.get(left) == nil {
+		c.report(n, "types incompatible for operation '%s' '%s' '%s'", left, n.Operator, right)
+		return Unknown
+	}
+
+	switch n.Operator {
+	case "<", ">", "<=", ">=":
+		return "Boolean"
+	case "<=>":
+		return "Number"
+	}
+	return left
+}
+
+// Returns the type of the arithmetic between times and durations, the only
+// builtin operators mixing types.
+func timeOperator(left, op, right string) (string, bool) {
+	switch {
+	case left == "Time" && right == "Time" && op == "-":
+		return "Duration", true
+	case left == "Time" && right == "Duration" && (op == "+" || op == "-"):
+		return "Time", true
+	case left == "Duration" && right == "Number" && (op == "*" || op == "/"):
+		return "Duration", true
+	case left == "Duration" && right == "Duration" && op == "/":
+		return "Number", true
+	}
+	return "", false
+}
+
+// Checks a function definition, optionally declaring it in the scope before
+// checking the body, so it can call itself.
+func (c *Checker) checkFunctionDef(s *scope, n *ast.FunctionDef, name string, declare bool) *function {
+	fn := &function{
+		name:      name,
+		generator: n.Generator,
+	}
+
+	inner := newScope(s)
+	for _, param := range n.Parameters {
+		tp := Unknown
+		ident, ok := param.(*ast.Identifier)
+		if spread, isSpread := param.(*ast.Spread); isSpread {
+			ident, ok = spread.Target.(*ast.Identifier)
+			fn.spread = true
+			tp = "List"
+		}
+		if !ok {
+			continue
+		}
+
+		if ident.Type != nil {
+			tp = c.resolveType(s, ident.Type)
+		}
+		fn.names = append(fn.names, ident.Value)
+		fn.params = append(fn.params, tp)
+		inner.set(ident.Value, &symbol{tp: tp})
+	}
+
+	if n.ReturnType != nil {
+		fn.returnType = c.resolveType(s, n.ReturnType)
+		if n.Generator && !c.compatible(s, fn.returnType, "Stream") {
+			c.report(n.ReturnType, "generator function '%s' returns 'Stream', not '%s'", name, fn.returnType)
+		}
+	} else if n.Generator {
+		fn.returnType = "Stream"
+	}
+
+	if declare {
+		s.set(name, &symbol{function: fn})
+	}
+
+	expected := fn.returnType
+	if n.Generator {
+		expected = Unknown
+	}
+	c.returns = append(c.returns, expected)
+	defer func() { c.returns = c.returns[:len(c.returns)-1] }()
+
+	// The last expression of the body is the implicit return value
+	body, ok := n.Body.(*ast.Block)
+	if !ok || len(body.Expressions) == 0 {
+		c.check(inner, n.Body)
+		return fn
+	}
+
+	for _, expr := range body.Expressions[:len(body.Expressions)-1] {
+		c.check(inner, expr)
+	}
+
+	last := body.Expressions[len(body.Expressions)-1]
+	tp := c.check(inner, last)
+	if !isControlFlow(last) {
+		c.checkReturn(inner, last, tp)
+	}
+	return fn
+}
+
+func (c *Checker) checkReturn(s *scope, node ast.Node, tp string) {
+	if len(c.returns) == 0 {
+		return
+	}
+
+	expected := c.returns[len(c.returns)-1]
+	if !c.compatible(s, expected, tp) {
+		c.report(node, "expected return value of type '%s', got '%s' instead", expected, tp)
+	}
+}
+
+func (c *Checker) checkDataDef(s *scope, n *ast.DataDef) {
+	d := &data{
+		name:       n.Name,
+		attributes: map[string]string{},
+	}
+
+	for _, ext := range n.Extensions {
+		ident, ok := ext.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+
+		if sym := s.get(ident.Value); sym != nil && sym.data != nil {
+			d.extends = append(d.extends, sym.data)
+			for name, tp := range sym.data.attributes {
+				d.attributes[name] = tp
+			}
+		}
+	}
+
+	if n.Name != "" {
+		s.set(n.Name, &symbol{data: d})
+	}
+
+	for name, node := range n.AttributeTypes {
+		d.attributes[name] = c.resolveType(s, node)
+	}
+
+	for name, node := range n.Attributes {
+		tp := c.check(s, node)
+		if expected, ok := d.attributes[name]; ok && !c.compatible(s, expected, tp) {
+			c.report(node, "expected attribute '%s' to be of type '%s', got '%s' instead", name, expected, tp)
+		}
+	}
+
+	for _, method := range n.Methods {
+		c.check(s, method)
+	}
+}
+
+func (c *Checker) checkCall(s *scope, n *ast.Call) string {
+	c.check(s, n.Target)
+
+	args := make([]string, len(n.Arguments))
+	hasSpread := false
+	for i, arg := range n.Arguments {
+		args[i] = c.check(s, arg)
+		if _, ok := arg.(*ast.Spread); ok {
+			hasSpread = true
+		}
+	}
+
+	ident, ok := n.Target.(*ast.Identifier)
+	if !ok {
+		return Unknown
+	}
+
+	sym := s.get(ident.Value)
+	switch {
+	case sym == nil && builtinTypes[ident.Value]:
+		return ident.Value
+
+	case sym == nil:
+		if tp, ok := builtinReturns[ident.Value]; ok {
+			return tp
+		}
+		return Unknown
+
+	case sym.data != nil:
+		return sym.data.name
+
+	case sym.function == nil:
+		return Unknown
+	}
+
+	fn := sym.function
+	if hasSpread {
+		return fn.returnType
+	}
+
+	required := len(fn.params)
+	if fn.spread {
+		required--
+	}
+	if len(args) < required {
+		c.report(n, "function '%s' expects %d arguments, got %d", fn.name, required, len(args))
+		return fn.returnType
+	}
+
+	for i, arg := range args {
+		if i >= len(fn.params) {
+			break
+		}
+
+		expected := fn.params[i]
+		if fn.spread && i == len(fn.params)-1 {
+			break
+		}
+
+		if !c.compatible(s, expected, arg) {
+			c.report(n.Arguments[i], "expected parameter '%s' of '%s' to be of type '%s', got '%s' instead", fn.names[i], fn.name, expected, arg)
+		}
+	}
+
+	return fn.returnType
+}
+
+func (c *Checker) checkInstantiate(s *scope, n *ast.Instantiate) string {
+	c.check(s, n.Target)
+
+	var d *data
+	if ident, ok := n.Target.(*ast.Identifier); ok {
+		if sym := s.get(ident.Value); sym != nil {
+			d = sym.data
+		}
+	}
+
+	for i := 0; i+1 < len(n.Elements); i += 2 {
+		tp := c.check(s, n.Elements[i+1])
+		if d == nil {
+			continue
+		}
+
+		key, ok := n.Elements[i].(*ast.String)
+		if !ok {
+			continue
+		}
+
+		if expected, ok := d.attributes[key.Value]; ok && !c.compatible(s, expected, tp) {
+			c.report(n.Elements[i+1], "expected attribute '%s' to be of type '%s', got '%s' instead", key.Value, expected, tp)
+		}
+	}
+
+	if d == nil {
+		return Unknown
+	}
+	return d.name
+}
+
+func isControlFlow(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.Return, *ast.Raise, *ast.Yield, *ast.For, *ast.Break, *ast.Continue:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package checker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/renatopp/pipelang/internal"
+	"github.com/renatopp/pipelang/internal/builtins"
+	"github.com/renatopp/pipelang/internal/object"
+)
+
+func TestBuiltinReturns(t *testing.T) {
+	scope := object.NewScope(nil)
+	builtins.RegisterBuiltinFunctions(scope)
+
+	for _, name := range scope.Keys() {
+		if _, ok := builtinReturns[name]; !ok {
+			t.Errorf("builtin function '%s' has no return type", name)
+		}
+	}
+
+	for name := range builtinReturns {
+		if scope.GetLocal(name) == nil {
+			t.Errorf("return type of '%s' does not match a builtin function", name)
+		}
+	}
+}
+
+// Parses and checks the code, returning the messages of the type errors.
+func check(t *testing.T, code string) []string {
+	t.Helper()
+
+	lexer := internal.NewPreLexer([]byte(code))
+	tokens := lexer.All()
+	if lexer.HasErrors() {
+		t.Fatalf("unexpected lexer errors: %v", lexer.Errors())
+	}
+
+	postLexer := internal.NewPostLexer(tokens)
+	if err := postLexer.Optimize(); err != nil {
+		t.Fatalf("unexpected lexer error: %v", err)
+	}
+
+	parser := internal.NewPipeParser(postLexer)
+	root := parser.Parse()
+	if parser.HasErrors() {
+		t.Fatalf("unexpected parser errors: %v", parser.Errors())
+	}
+
+	var messages []string
+	for _, err := range Check(root) {
+		messages = append(messages, err.Message)
+	}
+	return messages
+}
+
+func assertCheck(t *testing.T, code string, expected ...string) {
+	t.Helper()
+
+	messages := check(t, code)
+	if len(messages) != len(expected) {
+		t.Errorf("%s: expected %d type errors, got %d: %v", code, len(expected), len(messages), messages)
+		return
+	}
+	for i, message := range messages {
+		if message != expected[i] {
+			t.Errorf("%s: expected type error '%s', got '%s'", code, expected[i], message)
+		}
+	}
+}
+
+func TestCheck_Arguments(t *testing.T) {
+	for _, decl := range []string{
+		`fn f(a: Number, b: Number): Number { a + b }`,
+		`f := fn(a: Number, b: Number): Number { a + b }`,
+	} {
+		assertCheck(t, decl+`; f(1, 2)`)
+		assertCheck(t, decl+`; f('a', 2)`,
+			"expected parameter 'a' of 'f' to be of type 'Number', got 'String' instead")
+		assertCheck(t, decl+`; f(1, true)`,
+			"expected parameter 'b' of 'f' to be of type 'Number', got 'Boolean' instead")
+		assertCheck(t, decl+`; f(1)`,
+			"function 'f' expects 2 arguments, got 1")
+	}
+}
+
+func TestCheck_Returns(t *testing.T) {
+	for _, decl := range []string{
+		`fn f(): String { %s }`,
+		`f := fn(): String { %s }`,
+	} {
+		assertCheck(t, fmt.Sprintf(decl, `'a'`))
+		assertCheck(t, fmt.Sprintf(decl, `1`),
+			"expected return value of type 'String', got 'Number' instead")
+		assertCheck(t, fmt.Sprintf(decl, `return true`),
+			"expected return value of type 'String', got 'Boolean' instead")
+	}
+	assertCheck(t, `f := fn(n: Number): Number { if n < 1 { 1 } else { f('a') } }`,
+		"expected parameter 'n' of 'f' to be of type 'Number', got 'String' instead")
+}
+
+func TestCheck_Assignments(t *testing.T) {
+	for _, decl := range []string{
+		`fn f(a: Number, b: Number): Number { a + b }`,
+		`f := fn(a: Number, b: Number): Number { a + b }`,
+	} {
+		assertCheck(t, decl+`; z: Number := f(1, 2)`)
+		assertCheck(t, decl+`; z: String := f(1, 2)`,
+			"cannot assign 'Number' to 'z' of type 'String'")
+		assertCheck(t, decl+`; z := f(1, 2); z = 'a'`,
+			"cannot assign 'String' to 'z' of type 'Number'")
+		assertCheck(t, decl+`; f = fn(a: String) { a }; f('a')`)
+	}
+	assertCheck(t, `x: Number := 1; x = fn() { 1 }`,
+		"cannot assign 'Function' to 'x' of type 'Number'")
+	assertCheck(t, `f: Function := fn(a: Number) { a }; f('a')`,
+		"expected parameter 'a' of 'f' to be of type 'Number', got 'String' instead")
+}
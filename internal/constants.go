@@ -15,6 +15,7 @@ var Keywords = []string{
 	"break",
 	"continue",
 	"defer",
+	"go",
 
 	"true",
 	"false",
@@ -47,6 +47,25 @@ func FormatEvaluationError(err *internal.Error, source []byte, path string) erro
 	return formatErrorWithinSource("runtime", []errors.Error{err}, source, path)
 }
 
+// FormatCheckerErrors highlights every error found by the type checker, since
+// each one of them is an independent problem in the source.
+func FormatCheckerErrors(errs []*internal.Error, source []byte, path string) error {
+	result := ""
+	formatted := make([]errors.Error, len(errs))
+	for i, e := range errs {
+		formatted[i] = e
+		result += formatErrorWithinSource("type", []errors.Error{e}, source, path).Error()
+	}
+
+	return &Error{
+		Category: "type",
+		Errors:   formatted,
+		Source:   source,
+		Path:     path,
+		Message:  result,
+	}
+}
+
 func formatErrorWithinSource(category string, errs []errors.Error, source []byte, path string) error {
 	result := ""
 	for i, e := range errs {
@@ -20,6 +20,17 @@ type WithRecord struct {
 	Scope *o.Scope
 }
 
+// The operands evaluated before a yield paused the expression, so they are not
+// evaluated again when the stream resumes.
+type OperatorRecord struct {
+	Left o.Object
+}
+
+type CallRecord struct {
+	Target o.Object
+	Args   []o.Object
+}
+
 type MatchRecord struct {
 	Scope     *o.Scope
 	Case      int
@@ -1,6 +1,8 @@
 package evaluator
 
 import (
+	"fmt"
+
 	i "github.com/renatopp/pipelang/internal"
 	"github.com/renatopp/pipelang/internal/ast"
 	o "github.com/renatopp/pipelang/internal/object"
@@ -109,6 +111,9 @@ func (r *Evaluator) eval(scope *o.Scope, node ast.Node) o.Object {
 	case *ast.Index:
 		return r.evalIndex(scope, n)
 
+	case *ast.Slice:
+		return r.evalSlice(scope, n)
+
 	case *ast.Wrap:
 		return r.evalWrap(scope, n)
 
@@ -134,6 +139,15 @@ func (r *Evaluator) eval(scope *o.Scope, node ast.Node) o.Object {
 	case *ast.Continue:
 		return r.evalContinue(scope, n)
 
+	case *ast.Defer:
+		return r.evalDefer(scope, n)
+
+	case *ast.Go:
+		return r.evalGo(scope, n)
+
+	case *ast.Select:
+		return r.evalSelect(scope, n)
+
 	case *ast.If:
 		return r.evalIf(scope, n)
 
@@ -171,7 +185,9 @@ func (r *Evaluator) evalIdentifier(scope *o.Scope, n *ast.Identifier) o.Object {
 	if obj == nil {
 		return scope.Interrupt(o.Raise("identifier '%s' not found", n.Value))
 	}
-	obj.SetParent(nil)
+	if obj.Parent() != nil {
+		obj.SetParent(nil)
+	}
 	return obj
 }
 
@@ -236,18 +252,20 @@ func (r *Evaluator) evalDict(scope *o.Scope, n *ast.Dict) o.Object {
 
 func (r *Evaluator) evalFunctionDef(scope *o.Scope, n *ast.FunctionDef) o.Object {
 	fn := o.NewFunction(n.Name, n.Parameters, n.Body, scope)
+	fn.ReturnType = n.ReturnType
 
 	var ret o.Object = fn
 	if n.Generator {
 		ret = o.NewBuiltinFunction(n.Name, func(scope *o.Scope, args ...o.Object) o.Object {
 			genScope := fn.Scope.New()
+			genScope.NewDeferFrame()
 			params := &ast.Tuple{Elements: fn.Parameters}
 			ret := r.resolveAssignment(genScope, ":=", params, o.NewTuple(args...))
 			if isRaise(ret) {
 				return ret
 			}
 
-			return o.NewStream(fn, genScope)
+			return r.checkReturnType(genScope, fn, o.NewStream(fn, genScope))
 		})
 	}
 
@@ -259,7 +277,9 @@ func (r *Evaluator) evalFunctionDef(scope *o.Scope, n *ast.FunctionDef) o.Object
 
 func (r *Evaluator) evalDataDef(scope *o.Scope, n *ast.DataDef) o.Object {
 	attributes := map[string]ast.Node{}
+	attributeTypes := map[string]ast.Node{}
 	methods := map[string]*o.Function{}
+	extensions := []*o.DataType{}
 
 	for _, ext := range n.Extensions {
 		ext := r.eval(scope, ext)
@@ -275,13 +295,21 @@ func (r *Evaluator) evalDataDef(scope *o.Scope, n *ast.DataDef) o.Object {
 		for name, node := range data.Attributes {
 			attributes[name] = node
 		}
+		for name, node := range data.AttributeTypes {
+			attributeTypes[name] = node
+		}
 		for name, method := range data.Methods {
 			methods[name] = method
 		}
+		extensions = append(extensions, data)
 	}
 
 	for name, node := range n.Attributes {
 		attributes[name] = node
+		delete(attributeTypes, name)
+	}
+	for name, node := range n.AttributeTypes {
+		attributeTypes[name] = node
 	}
 	for name, method := range n.Methods {
 		fn := r.eval(scope, method)
@@ -292,6 +320,8 @@ func (r *Evaluator) evalDataDef(scope *o.Scope, n *ast.DataDef) o.Object {
 	}
 
 	data := o.NewDataType(n.Name, attributes, methods)
+	data.AttributeTypes = attributeTypes
+	data.Extensions = extensions
 	if n.Name != "" {
 		scope.SetLocal(n.Name, data)
 	}
@@ -326,13 +356,31 @@ func (r *Evaluator) evalPrefixOperator(scope *o.Scope, n *ast.PrefixOperator) o.
 }
 
 func (r *Evaluator) evalInfixOperator(scope *o.Scope, n *ast.InfixOperator) o.Object {
-	left := r.eval(scope, n.Left)
+	var left o.Object
+	if ar := scope.NodeRecord(n); ar != nil {
+		left = ar.(*OperatorRecord).Left
+	} else {
+		left = r.eval(scope, n.Left)
+		if isYield(left) {
+			return left
+		}
+	}
+
+	// The right side may pause the stream, the left side is kept for resuming
+	evalRight := func() o.Object {
+		right := r.eval(scope, n.Right)
+		if isYield(right) {
+			scope.SetNodeRecord(n, &OperatorRecord{Left: left})
+		}
+		return right
+	}
+
 	if n.Operator == "??" {
 		maybe := o.NewMaybe(left)
 		if maybe.Ok {
 			return maybe.Value
 		}
-		return r.eval(scope, n.Right)
+		return evalRight()
 	}
 
 	if isRaise(left) {
@@ -343,18 +391,18 @@ func (r *Evaluator) evalInfixOperator(scope *o.Scope, n *ast.InfixOperator) o.Ob
 		if !left.AsBool() {
 			return o.False
 		}
-		return r.eval(scope, n.Right)
+		return evalRight()
 	}
 
 	if n.Operator == "or" {
 		if left.AsBool() {
 			return o.True
 		}
-		return r.eval(scope, n.Right)
+		return evalRight()
 	}
 
-	right := r.eval(scope, n.Right)
-	if isRaise(right) {
+	right := evalRight()
+	if isRaise(right) || isYield(right) {
 		return right
 	}
 
@@ -395,39 +443,93 @@ func (r *Evaluator) evalOperator(scope *o.Scope, op string, left, right o.Object
 		return left.(*o.String).OnOperator(scope, op, right)
 
 	case op == "==":
-		return o.NewBoolean(left.Id() == right.Id())
+		return o.Equals(scope, left, right)
 
 	case op == "!=":
-		return o.NewBoolean(left.Id() != right.Id())
+		res := o.Equals(scope, left, right)
+		if isRaise(res) {
+			return res
+		}
+		return o.NewBoolean(!res.AsBool())
+
+	case leftTypeId == o.DataId:
+		return left.OnOperator(scope, op, right)
+
+	case leftTypeId == o.TimeId || leftTypeId == o.DurationId:
+		return left.OnOperator(scope, op, right)
 
 	case leftTypeId != rightTypeId:
 		return scope.Interrupt(o.Raise("types incompatible for operation '%s' '%s' '%s'", leftTypeId, op, rightTypeId))
 
 	default:
-		return scope.Interrupt(o.Raise("unknown binary operator '%s'", op))
+		return left.OnOperator(scope, op, right)
 	}
 }
 
 func (r *Evaluator) evalCall(scope *o.Scope, n *ast.Call) o.Object {
-	obj := r.eval(scope, n.Target)
-	if isRaise(obj) {
-		return obj
+	obj, args, err := r.evalCallTarget(scope, n)
+	if err != nil {
+		return err
+	}
+
+	if tracer := scope.Tracer(); tracer != nil && n.Token.IsType(i.T_PIPE) {
+		return tracer.Trace(scope, callName(n.Target), n.Token.FromLine, n.Token.FromColumn, func() o.Object {
+			return r.call(scope, obj, args)
+		})
+	}
+
+	return r.call(scope, obj, args)
+}
+
+// Returns the name of the call target as written in the code, like `map` or
+// `csv.Lines`.
+func callName(target ast.Node) string {
+	switch n := target.(type) {
+	case *ast.Identifier:
+		return n.Value
+	case *ast.Access:
+		return callName(n.Left) + "." + callName(n.Right)
 	}
+	return "?"
+}
 
-	// Inject `this` if it is a method call
-	args := []o.Object{}
-	if p := obj.Parent(); p != nil {
-		args = append(args, p)
+// Evaluates the target and the arguments of a call, without calling it.
+func (r *Evaluator) evalCallTarget(scope *o.Scope, n *ast.Call) (o.Object, []o.Object, o.Object) {
+	// Resuming a call paused by a yield in its arguments
+	var obj o.Object
+	var args []o.Object
+	if ar := scope.NodeRecord(n); ar != nil {
+		state := ar.(*CallRecord)
+		obj, args = state.Target, state.Args
+	} else {
+		obj = r.eval(scope, n.Target)
+		if isRaise(obj) {
+			return nil, nil, obj
+		}
+
+		// Inject `this` if it is a method call
+		args = []o.Object{}
+		if p := obj.Parent(); p != nil {
+			args = append(args, p)
+		}
 	}
-	for _, arg := range n.Arguments {
+
+	evaluated := len(args)
+	if obj.Parent() != nil {
+		evaluated--
+	}
+	for _, arg := range n.Arguments[evaluated:] {
 		item := r.eval(scope, arg)
-		if isRaise(item) {
-			return item
+		if isYield(item) {
+			scope.SetNodeRecord(n, &CallRecord{Target: obj, Args: args})
+		}
+		if isRaise(item) || isYield(item) {
+			return nil, nil, item
 		}
 		args = append(args, item)
 	}
 
-	return r.call(scope, obj, args)
+	return obj, args, nil
 }
 
 func (r *Evaluator) evalInstantiate(scope *o.Scope, n *ast.Instantiate) o.Object {
@@ -454,6 +556,12 @@ func (r *Evaluator) evalInstantiate(scope *o.Scope, n *ast.Instantiate) o.Object
 		if left == nil {
 			return scope.Interrupt(o.Raise("property '%s' not found in type '%s'", key.Value, target.TypeId()))
 		}
+
+		if dt, ok := target.(*o.DataType); ok {
+			if err := r.checkAttributeType(scope, dt, key.Value, value); err != nil {
+				return err
+			}
+		}
 		left.SetParent(obj)
 		r.assign(scope, "=", key.Value, left, value)
 	}
@@ -475,6 +583,9 @@ func (r *Evaluator) instantiate(scope *o.Scope, target o.Object) o.Object {
 				return value
 			}
 
+			if err := r.checkAttributeType(scope, target, name, value); err != nil {
+				return err
+			}
 			obj.SetProperty(name, value)
 		}
 		return obj
@@ -491,6 +602,10 @@ func (r *Evaluator) instantiate(scope *o.Scope, target o.Object) o.Object {
 }
 
 func (r *Evaluator) call(scope *o.Scope, target o.Object, args []o.Object) o.Object {
+	if err := scope.Cancelled(); err != nil {
+		return err
+	}
+
 	switch target := target.(type) {
 	case *o.BuiltinFunction:
 		return r.callBuiltinFunction(scope, target, args)
@@ -519,21 +634,68 @@ func (r *Evaluator) callDataType(scope *o.Scope, dt *o.DataType, args []o.Object
 }
 
 func (r *Evaluator) callFunction(scope *o.Scope, fn *o.Function, args []o.Object) o.Object {
-	fnScope := fn.Scope.New()
+	// The body is cancelled with the caller, not where it was defined
+	fnScope := fn.Scope.New().WithCancel(scope.Done())
+	fnScope.NewDeferFrame()
 	params := &ast.Tuple{Elements: fn.Parameters}
-	ret := r.resolveAssignment(scope, ":=", params, o.NewTuple(args...))
+	ret := r.resolveAssignment(fnScope, ":=", params, o.NewTuple(args...))
 	if isRaise(ret) {
 		return ret
 	}
 
 	ret = r.eval(fnScope, fn.Body)
 	if t := asReturn(ret); t != nil {
-		return t.Value
+		ret = t.Value
+	}
+
+	if err := fnScope.RunDeferred(); err != nil && !isRaise(ret) {
+		ret = err
 	}
 
+	if asInterruption(ret) != nil {
+		return ret
+	}
+	return r.checkReturnType(fnScope, fn, ret)
+}
+
+// Raises if the function has a return type annotation that doesn't match the
+// returned value.
+func (r *Evaluator) checkReturnType(scope *o.Scope, fn *o.Function, ret o.Object) o.Object {
+	if fn.ReturnType == nil {
+		return ret
+	}
+
+	tp := r.eval(scope, fn.ReturnType)
+	if isRaise(tp) {
+		return tp
+	}
+
+	name := "return value"
+	if fn.Name != "" {
+		name = fmt.Sprintf("return value of '%s'", fn.Name)
+	}
+	if err := o.CheckType(scope, name, ret, tp); err != nil {
+		return err
+	}
 	return ret
 }
 
+// Raises if the data type has a type annotation for the attribute that doesn't
+// match the value.
+func (r *Evaluator) checkAttributeType(scope *o.Scope, dt *o.DataType, name string, value o.Object) o.Object {
+	node, ok := dt.AttributeTypes[name]
+	if !ok {
+		return nil
+	}
+
+	tp := r.eval(scope, node)
+	if isRaise(tp) {
+		return tp
+	}
+
+	return o.CheckType(scope, fmt.Sprintf("attribute '%s'", name), value, tp)
+}
+
 func (r *Evaluator) callType(scope *o.Scope, ot o.ObjectType, args []o.Object) o.Object {
 	if len(args) > 0 {
 		return ot.Convert(scope, args[0])
@@ -572,7 +734,7 @@ func (r *Evaluator) callBuiltinFunction(scope *o.Scope, fn *o.BuiltinFunction, a
 
 func (r *Evaluator) evalAssignment(scope *o.Scope, n *ast.Assignment) o.Object {
 	right := r.eval(scope, n.Right)
-	if isRaise(right) {
+	if isRaise(right) || isYield(right) {
 		return right
 	}
 
@@ -595,6 +757,16 @@ func (r *Evaluator) evalAssignment(scope *o.Scope, n *ast.Assignment) o.Object {
 func (r *Evaluator) resolveAssignment(scope *o.Scope, op string, left ast.Node, right o.Object) o.Object {
 	switch left := left.(type) {
 	case *ast.Identifier:
+		if left.Type != nil {
+			tp := r.eval(scope, left.Type)
+			if isRaise(tp) {
+				return tp
+			}
+
+			if err := o.CheckType(scope, fmt.Sprintf("'%s'", left.Value), right, tp); err != nil {
+				return err
+			}
+		}
 		return r.assign(scope, op, left.Value, scope.GetGlobal(left.Value), right)
 
 	case *ast.Access:
@@ -759,6 +931,9 @@ func (r *Evaluator) evalAccess(scope *o.Scope, n *ast.Access) o.Object {
 			if ok && data.Attributes[right.Value] != nil {
 				return p
 			}
+			if fn, ok := p.(*o.BuiltinFunction); ok {
+				return fn.Bind(left)
+			}
 			p.SetParent(left)
 		}
 		return p
@@ -786,8 +961,34 @@ func (r *Evaluator) evalIndex(scope *o.Scope, n *ast.Index) o.Object {
 	return target.OnIndex(scope, index.(*o.Tuple))
 }
 
+func (r *Evaluator) evalSlice(scope *o.Scope, n *ast.Slice) o.Object {
+	parts := []*o.Number{}
+	for _, node := range []ast.Node{n.Start, n.Stop, n.Step} {
+		if node == nil {
+			parts = append(parts, nil)
+			continue
+		}
+
+		value := r.eval(scope, node)
+		if isRaise(value) {
+			return value
+		}
+
+		number, ok := value.(*o.Number)
+		if !ok {
+			return scope.Interrupt(o.Raise("slice indices must be numbers, got '%s'", value.TypeId()))
+		}
+		parts = append(parts, number)
+	}
+
+	return o.NewSlice(parts[0], parts[1], parts[2])
+}
+
 func (r *Evaluator) evalWrap(scope *o.Scope, n *ast.Wrap) o.Object {
 	target := r.eval(scope, n.Target)
+	if isYield(target) {
+		return target
+	}
 
 	if t := asRaise(target); t != nil {
 		return o.NewMaybe(t.Value)
@@ -798,7 +999,7 @@ func (r *Evaluator) evalWrap(scope *o.Scope, n *ast.Wrap) o.Object {
 
 func (r *Evaluator) evalUnwrap(scope *o.Scope, n *ast.Unwrap) o.Object {
 	target := r.eval(scope, n.Target)
-	if isRaise(target) {
+	if isRaise(target) || isYield(target) {
 		return target
 	}
 
@@ -831,15 +1032,13 @@ func (r *Evaluator) evalBlock(scope *o.Scope, n *ast.Block) o.Object {
 		statement := n.Expressions[i]
 		result := r.eval(blockScope, statement)
 
+		// The statement holding the yield is evaluated again when the stream
+		// resumes, so the paused yield can evaluate to the value sent into it.
+		// The operands evaluated before the yield are kept in node records.
 		if t := asYield(result); t != nil {
-			idx := i
-			if t.TriggeredScope == blockScope {
-				idx = i + 1
-			}
-
 			scope.SetActiveRecord(&BlockRecord{
 				Scope:     blockScope,
-				Statement: idx,
+				Statement: i,
 			})
 		}
 
@@ -880,14 +1079,197 @@ func (r *Evaluator) evalYield(scope *o.Scope, n *ast.Yield) o.Object {
 		return scope.Interrupt(o.ReturnWith(o.False))
 	}
 
+	// When resuming the stream, the first yield evaluated is the paused one,
+	// which evaluates to the value sent into the stream
+	stream, _ := scope.GetGlobal(o.StreamKey).(*o.Stream)
+	if stream != nil && stream.Suspended {
+		if stream.Delegate != nil {
+			return r.delegateYield(scope, stream, stream.Delegate)
+		}
+
+		sent, thrown := stream.Sent, stream.Thrown
+		stream.Suspended = false
+		stream.Sent = nil
+		stream.Thrown = nil
+
+		if thrown != nil {
+			return scope.Interrupt(o.RaiseWith(thrown))
+		}
+		if sent == nil {
+			return o.False
+		}
+		return sent
+	}
+
 	right := r.eval(scope, n.Expression)
-	if isRaise(right) {
+	if isRaise(right) || isYield(right) {
 		return right
 	}
 
+	if n.Delegate {
+		sub := o.StreamTypeObj.Convert(scope, right)
+		if isRaise(sub) {
+			return sub
+		}
+		return r.delegateYield(scope, stream, sub.(*o.Stream))
+	}
+
+	if stream != nil {
+		stream.Suspended = true
+	}
 	return scope.Interrupt(o.YieldWith(right))
 }
 
+// Forwards the values, sends and throws of the outer stream to the delegated
+// one, until it finishes. The `yield from` expression evaluates to false.
+func (r *Evaluator) delegateYield(scope *o.Scope, stream, sub *o.Stream) o.Object {
+	var ret o.Object
+	switch {
+	case stream != nil && stream.Thrown != nil:
+		ret = o.Stream_Throw.Call(scope, sub, stream.Thrown)
+	case stream != nil && stream.Sent != nil:
+		ret = o.Stream_Send.Call(scope, sub, stream.Sent)
+	default:
+		ret = o.Stream_Next.Call(scope, sub)
+	}
+
+	if stream != nil {
+		stream.Suspended = false
+		stream.Sent = nil
+		stream.Thrown = nil
+		stream.Delegate = nil
+	}
+
+	if isRaise(ret) {
+		return ret
+	}
+
+	if sub.Finished {
+		return o.False
+	}
+
+	if stream != nil {
+		stream.Suspended = true
+		stream.Delegate = sub
+	}
+	return scope.Interrupt(o.YieldWith(ret.(*o.Maybe).Value))
+}
+
+func (r *Evaluator) evalGo(scope *o.Scope, n *ast.Go) o.Object {
+	taskScope := scope.New()
+	run := func() o.Object {
+		return r.eval(taskScope, n.Expression)
+	}
+
+	// Calls have the target and arguments evaluated right away, so the task
+	// does not see the changes made to them afterwards
+	if call, ok := n.Expression.(*ast.Call); ok {
+		target, args, err := r.evalCallTarget(scope, call)
+		if err != nil {
+			return err
+		}
+		run = func() o.Object {
+			return r.call(taskScope, target, args)
+		}
+	}
+
+	task := o.NewTask()
+	go func() {
+		taskScope.Acquire()
+		defer taskScope.Release()
+		task.Finish(run())
+	}()
+	return task
+}
+
+func (r *Evaluator) evalSelect(scope *o.Scope, n *ast.Select) o.Object {
+	ops := []o.ChannelOp{}
+	cases := []int{} // index of the case of each operation
+	fallback := -1
+	for i := 0; i < len(n.Cases); i += 2 {
+		op, isDefault, err := r.evalSelectCase(scope, n.Cases[i])
+		if err != nil {
+			return err
+		}
+
+		if isDefault {
+			fallback = i / 2
+			continue
+		}
+		ops = append(ops, op)
+		cases = append(cases, i/2)
+	}
+
+	chosen, value := o.SelectChannels(scope, ops, fallback < 0)
+	if isRaise(value) {
+		return value
+	}
+
+	c := fallback
+	if chosen >= 0 {
+		c = cases[chosen]
+	}
+
+	selectScope := scope.New()
+	if name := n.Names[c]; name != "" {
+		if value == nil {
+			value = o.False
+		}
+		selectScope.SetLocal(name, value)
+	}
+	return r.eval(selectScope, n.Cases[c*2+1])
+}
+
+// Evaluates the channel and the value of a select case, without running the
+// operation. Cases are `ch.Send(value)`, `ch.Receive()`, any expression
+// returning a channel to receive from, like `after(100)`, or `_` for default.
+func (r *Evaluator) evalSelectCase(scope *o.Scope, node ast.Node) (o.ChannelOp, bool, o.Object) {
+	if ident, ok := node.(*ast.Identifier); ok && ident.Value == "_" {
+		return o.ChannelOp{}, true, nil
+	}
+
+	target := node
+	var value o.Object
+	if call, ok := node.(*ast.Call); ok {
+		if access, ok := call.Target.(*ast.Access); ok {
+			method, _ := access.Right.(*ast.Identifier)
+			switch {
+			case method != nil && method.Value == "Receive" && len(call.Arguments) == 0:
+				target = access.Left
+
+			case method != nil && method.Value == "Send" && len(call.Arguments) == 1:
+				target = access.Left
+				value = r.eval(scope, call.Arguments[0])
+				if isRaise(value) {
+					return o.ChannelOp{}, false, value
+				}
+			}
+		}
+	}
+
+	obj := r.eval(scope, target)
+	if isRaise(obj) {
+		return o.ChannelOp{}, false, obj
+	}
+
+	ch, ok := obj.(*o.Channel)
+	if !ok {
+		return o.ChannelOp{}, false, scope.Interrupt(o.Raise("select cases must be channel operations, got '%s'", obj.TypeId()))
+	}
+	return o.ChannelOp{Channel: ch, Value: value}, false, nil
+}
+
+func (r *Evaluator) evalDefer(scope *o.Scope, n *ast.Defer) o.Object {
+	ok := scope.Defer(func() o.Object {
+		return r.eval(scope, n.Expression)
+	})
+
+	if !ok {
+		return scope.Interrupt(o.Raise("defer can only be used inside functions"))
+	}
+	return o.False
+}
+
 func (r *Evaluator) evalBreak(scope *o.Scope, _ *ast.Break) o.Object {
 	return scope.Interrupt(&o.Interruption{
 		Category: o.BreakId,
@@ -962,6 +1344,9 @@ func (r *Evaluator) evalFor(scope *o.Scope, n *ast.For) o.Object {
 		if !conditionSolved && len(n.Conditions) > 0 {
 			ret, shouldReturn := r.checkForCondition(forScope, n)
 			if shouldReturn {
+				if isRaise(ret) {
+					r.closeForStream(forScope)
+				}
 				return ret
 			}
 		}
@@ -975,12 +1360,20 @@ func (r *Evaluator) evalFor(scope *o.Scope, n *ast.For) o.Object {
 
 		conditionSolved = false
 		if t := asInterruption(res); t != nil {
-			if t.Category == o.BreakId {
-				break
-			}
 			if t.Category == o.ContinueId {
 				continue
 			}
+
+			// Leaving the loop early closes the stream being iterated
+			if t.Category != o.YieldId {
+				if err := r.closeForStream(forScope); err != nil && t.Category != o.RaiseId {
+					return err
+				}
+			}
+
+			if t.Category == o.BreakId {
+				break
+			}
 			forScope.SetLocal(ForReturnKey, t.Value)
 			return t
 		}
@@ -991,6 +1384,14 @@ func (r *Evaluator) evalFor(scope *o.Scope, n *ast.For) o.Object {
 	return forScope.GetLocal(ForReturnKey)
 }
 
+func (r *Evaluator) closeForStream(scope *o.Scope) o.Object {
+	stream, ok := scope.GetLocal(ForInKey).(*o.Stream)
+	if !ok {
+		return nil
+	}
+	return stream.Close(scope)
+}
+
 func (r *Evaluator) checkForCondition(scope *o.Scope, n *ast.For) (res o.Object, shouldReturn bool) {
 	// Run first conditions
 	for _, condition := range n.Conditions[:len(n.Conditions)-1] {
@@ -1211,6 +1612,10 @@ func asYield(obj o.Object) *o.Interruption {
 	return nil
 }
 
+func isYield(obj o.Object) bool {
+	return asYield(obj) != nil
+}
+
 func isIteration(obj o.Object) bool {
 	_, ok := obj.(*o.StreamIteration)
 	return ok
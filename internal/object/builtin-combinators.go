@@ -0,0 +1,399 @@
+package object
+
+var Zip = F(
+	func(scope *Scope, args ...Object) Object {
+		streams, err := valuesOfAll(scope, args)
+		if err != nil {
+			return err
+		}
+
+		return NewInternalStream(func(s *Scope) Object {
+			if len(streams) == 0 {
+				return nil
+			}
+
+			values := make([]Object, len(streams))
+			for i, stream := range streams {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					return nil
+				}
+				values[i] = maybe.(*Maybe).Value
+			}
+			return YieldWith(NewTuple(values...))
+		}, scope).WithUpstream(streams...)
+	},
+	`zip`,
+	`Combines the streams into a stream of tuples, stopping at the shortest one.`,
+	P("streams").AsSpread(),
+)
+
+var ZipLongest = F(
+	func(scope *Scope, args ...Object) Object {
+		streams, err := valuesOfAll(scope, args)
+		if err != nil {
+			return err
+		}
+
+		return NewInternalStream(func(s *Scope) Object {
+			values := make([]Object, len(streams))
+			finished := true
+			for i, stream := range streams {
+				values[i] = False
+				if stream.Finished {
+					continue
+				}
+
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					continue
+				}
+
+				finished = false
+				values[i] = maybe.(*Maybe).Value
+			}
+
+			if finished {
+				return nil
+			}
+			return YieldWith(NewTuple(values...))
+		}, scope).WithUpstream(streams...)
+	},
+	`zipLongest`,
+	`Combines the streams into a stream of tuples, stopping at the longest one. Missing values are false.`,
+	P("streams").AsSpread(),
+)
+
+var Enumerate = F(
+	func(scope *Scope, args ...Object) Object {
+		stream, err := valuesOf(scope, args[0])
+		if err != nil {
+			return err
+		}
+
+		idx := 0.
+		if len(args) > 1 {
+			idx = args[1].(*Number).Value
+		}
+
+		return NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			ret := NewTuple(maybe.(*Maybe).Value, NewNumber(idx))
+			idx++
+			return YieldWith(ret)
+		}, scope).WithUpstream(stream)
+	},
+	`enumerate`,
+	`Yields each element of the stream with its index, as (value, index) tuples. The index starts at zero, unless another start is given.`,
+	P("stream"),
+	P("start"),
+)
+
+var Chain = F(
+	func(scope *Scope, args ...Object) Object {
+		streams, err := valuesOfAll(scope, args)
+		if err != nil {
+			return err
+		}
+
+		cur := 0
+		return NewInternalStream(func(s *Scope) Object {
+			for cur < len(streams) {
+				stream := streams[cur]
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					cur++
+					continue
+				}
+				return YieldWith(maybe.(*Maybe).Value)
+			}
+			return nil
+		}, scope).WithUpstream(streams...)
+	},
+	`chain`,
+	`Yields all elements of the first stream, then all elements of the second, and so on.`,
+	P("streams").AsSpread(),
+)
+
+var Concat = F(
+	func(scope *Scope, args ...Object) Object {
+		return Chain.Call(scope, args...)
+	},
+	`concat`,
+	`Alias of chain.`,
+	P("streams").AsSpread(),
+)
+
+var Interleave = F(
+	func(scope *Scope, args ...Object) Object {
+		streams, err := valuesOfAll(scope, args)
+		if err != nil {
+			return err
+		}
+
+		cur := 0
+		return NewInternalStream(func(s *Scope) Object {
+			for range streams {
+				stream := streams[cur]
+				cur = (cur + 1) % len(streams)
+				if stream.Finished {
+					continue
+				}
+
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					continue
+				}
+				return YieldWith(maybe.(*Maybe).Value)
+			}
+			return nil
+		}, scope).WithUpstream(streams...)
+	},
+	`interleave`,
+	`Yields one element of each stream in turn, skipping the finished ones.`,
+	P("streams").AsSpread(),
+)
+
+var Product = F(
+	func(scope *Scope, args ...Object) Object {
+		streams, err := valuesOfAll(scope, args)
+		if err != nil {
+			return err
+		}
+
+		// The first stream is consumed lazily, the others are collected once,
+		// since they are repeated for every element of the first one
+		var head *Stream
+		var current Object
+		ready := false
+		pools := make([][]Object, len(streams))
+		indices := make([]int, len(streams))
+		if len(streams) > 0 {
+			head = streams[0]
+		}
+
+		return NewInternalStream(func(s *Scope) Object {
+			if head == nil {
+				return nil
+			}
+
+			if !ready {
+				ready = true
+				for i, stream := range streams[1:] {
+					ret := stream.Resolve(func(value Object) Object {
+						pools[i+1] = append(pools[i+1], value)
+						return nil
+					})
+					if isRaise(ret) {
+						return ret
+					}
+					if len(pools[i+1]) == 0 {
+						return nil
+					}
+				}
+			}
+
+			// Advances the indices like an odometer, pulling a new element
+			// from the first stream when the other ones wrap around
+			last := len(streams) - 1
+			for i := last; i > 0 && current != nil; i-- {
+				indices[i]++
+				if indices[i] < len(pools[i]) {
+					break
+				}
+
+				indices[i] = 0
+				if i == 1 {
+					current = nil
+				}
+			}
+
+			if current == nil || last == 0 {
+				maybe := Stream_Next.Call(s, head)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if head.Finished {
+					return nil
+				}
+				current = maybe.(*Maybe).Value
+			}
+
+			values := []Object{current}
+			for i := 1; i <= last; i++ {
+				values = append(values, pools[i][indices[i]])
+			}
+			return YieldWith(NewTuple(values...))
+		}, scope).WithUpstream(streams...)
+	},
+	`product`,
+	`Yields the cartesian product of the streams as tuples. Only the first stream is consumed lazily.`,
+	P("streams").AsSpread(),
+)
+
+var FlatMap = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		return flatStream(scope, stream, func(value Object) Object {
+			return scope.Eval().Call(scope, f, toLambdaParams(value))
+		})
+	},
+	`flatMap`,
+	`Maps each element of the stream and flattens the result. Lists, tuples and streams returned by the function are expanded into their elements.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Flatten = F(
+	func(scope *Scope, args ...Object) Object {
+		stream, err := valuesOf(scope, args[0])
+		if err != nil {
+			return err
+		}
+
+		return flatStream(scope, stream, func(value Object) Object {
+			return value
+		})
+	},
+	`flatten`,
+	`Expands the lists, tuples and streams inside the stream into their elements, one level deep.`,
+	P("stream"),
+)
+
+var Recurse = F(
+	func(scope *Scope, args ...Object) Object {
+		var value Object
+		f := args[1]
+		return NewInternalStream(func(s *Scope) Object {
+			if value == nil {
+				value = args[0]
+				return YieldWith(value)
+			}
+
+			value = scope.Eval().Call(scope, f, []Object{value})
+			if isRaise(value) {
+				return value
+			}
+			return YieldWith(value)
+		}, scope)
+	},
+	`recurse`,
+	`Yields the initial value, then the result of the function applied to the previous value, indefinitely.`,
+	P("initial"),
+	P("f", V.Type(FunctionId)),
+)
+
+// Yields the elements of each value returned by fn, expanding lists, tuples
+// and streams.
+func flatStream(scope *Scope, stream *Stream, fn func(Object) Object) *Stream {
+	var inner *Stream
+	result := NewInternalStream(func(s *Scope) Object {
+		for {
+			if inner != nil {
+				maybe := Stream_Next.Call(s, inner)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if !inner.Finished {
+					return YieldWith(maybe.(*Maybe).Value)
+				}
+				inner = nil
+			}
+
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			value := fn(maybe.(*Maybe).Value)
+			if isRaise(value) {
+				return value
+			}
+
+			switch value.(type) {
+			case *List, *Tuple, *Stream:
+				ret, err := valuesOf(scope, value)
+				if err != nil {
+					return err
+				}
+				inner = ret
+			default:
+				return YieldWith(value)
+			}
+		}
+	}, scope).WithUpstream(stream)
+
+	return result.OnClose(func(s *Scope) Object {
+		if inner != nil {
+			return inner.Close(s)
+		}
+		return nil
+	})
+}
+
+// Converts the object into a stream of its values. Unlike the Stream
+// conversion, lists and tuples yield their elements without indices.
+func valuesOf(scope *Scope, obj Object) (*Stream, Object) {
+	var elements []Object
+	switch obj := obj.(type) {
+	case *List:
+		elements = obj.Elements
+	case *Tuple:
+		elements = obj.Elements
+	default:
+		s := StreamTypeObj.Convert(scope, obj)
+		if isRaise(s) {
+			return nil, s
+		}
+		return s.(*Stream), nil
+	}
+
+	idx := 0
+	return NewInternalStream(func(s *Scope) Object {
+		if idx >= len(elements) {
+			return nil
+		}
+		idx++
+		return YieldWith(elements[idx-1])
+	}, scope), nil
+}
+
+func valuesOfAll(scope *Scope, objs []Object) ([]*Stream, Object) {
+	streams := make([]*Stream, len(objs))
+	for i, obj := range objs {
+		s, err := valuesOf(scope, obj)
+		if err != nil {
+			return nil, err
+		}
+		streams[i] = s
+	}
+	return streams, nil
+}
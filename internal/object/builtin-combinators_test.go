@@ -0,0 +1,50 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestFunction_Zip(t *testing.T) {
+	common.AssertCode(t, ` zip([1,2,3], ['a','b']) | List`, `[(1, 'a'), (2, 'b')]`)
+	common.AssertCode(t, ` zip(range(), 'ab') | List`, `[(0, 'a'), (1, 'b')]`)
+	common.AssertCode(t, ` r := ''; for h, c in zip(['h1','h2'], ['c1','c2']) { r ..= h .. c }; r`, `h1c1h2c2`)
+}
+
+func TestFunction_ZipLongest(t *testing.T) {
+	common.AssertCode(t, ` zipLongest([1,2,3], ['a']) | List`, `[(1, 'a'), (2, false), (3, false)]`)
+}
+
+func TestFunction_Enumerate(t *testing.T) {
+	common.AssertCode(t, ` ['a','b'] | enumerate | List`, `[('a', 0), ('b', 1)]`)
+	common.AssertCode(t, ` ['a','b'] | enumerate 1 | List`, `[('a', 1), ('b', 2)]`)
+}
+
+func TestFunction_Chain(t *testing.T) {
+	common.AssertCode(t, ` chain([1,2], range(3,5)) | List`, `[1, 2, 3, 4]`)
+	common.AssertCode(t, ` concat([1], [], [2]) | List`, `[1, 2]`)
+}
+
+func TestFunction_Interleave(t *testing.T) {
+	common.AssertCode(t, ` interleave([1,2,3], ['a'], [9,8]) | List`, `[1, 'a', 9, 2, 8, 3]`)
+}
+
+func TestFunction_Product(t *testing.T) {
+	common.AssertCode(t, ` product([1,2], ['a','b']) | List`, `[(1, 'a'), (1, 'b'), (2, 'a'), (2, 'b')]`)
+	common.AssertCode(t, ` product(range(), [true]) | take 2 | List`, `[(0, true), (1, true)]`)
+	common.AssertCode(t, ` product([1,2], []) | List`, `[]`)
+}
+
+func TestFunction_FlatMap(t *testing.T) {
+	common.AssertCode(t, ` [1,2] | flatMap x: [x, x*10] | List`, `[1, 10, 2, 20]`)
+	common.AssertCode(t, ` [1,2] | flatMap x: range(x) | List`, `[0, 0, 1]`)
+}
+
+func TestFunction_Flatten(t *testing.T) {
+	common.AssertCode(t, ` [[1,2],[3],4] | flatten | List`, `[1, 2, 3, 4]`)
+}
+
+func TestFunction_Recurse(t *testing.T) {
+	common.AssertCode(t, ` recurse(1, x: x * 2) | take 5 | List`, `[1, 2, 4, 8, 16]`)
+}
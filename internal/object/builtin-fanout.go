@@ -0,0 +1,196 @@
+package object
+
+// forkBuffer is the number of elements each branch of a fork may fall behind
+// the others.
+const forkBuffer = 64
+
+var Tee = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := 2
+		if len(args) > 1 {
+			num, ok := args[1].(*Number)
+			if !ok || num.Value < 1 {
+				return scope.Interrupt(Raise("tee requires a positive number of streams, got '%s'", args[1].AsString()))
+			}
+			n = int(num.Value)
+		}
+
+		t := &teeState{
+			upstream: stream,
+			pos:      make([]int, n),
+			closed:   make([]bool, n),
+		}
+
+		branches := make([]Object, n)
+		for i := range n {
+			branch := NewInternalStream(func(s *Scope) Object {
+				return t.next(s, i)
+			}, scope).OnClose(func(s *Scope) Object {
+				return t.close(s, i)
+			})
+			branch.Indexed = stream.Indexed
+			branches[i] = branch
+		}
+		return NewTuple(branches...)
+	},
+	`tee`,
+	`Splits the stream into a tuple of n independent streams (2 by default). Elements are buffered only until all streams consume them, so streams consumed side by side keep the memory flat.`,
+	P("stream"),
+	P("n"),
+)
+
+var Fork = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		// Each function consumes its own channel in a task, so all branches
+		// advance together
+		fns := args[1:]
+		channels := make([]*Channel, len(fns))
+		tasks := make([]*Task, len(fns))
+		for i, f := range fns {
+			ch := NewChannel(forkBuffer)
+			task := NewTask()
+			branchScope := scope.New()
+			channels[i], tasks[i] = ch, task
+
+			go func() {
+				branchScope.Acquire()
+				defer branchScope.Release()
+
+				ret := branchScope.Eval().Call(branchScope, f, []Object{ch.Stream(branchScope)})
+				if !ch.IsClosed() {
+					ch.Close(branchScope)
+				}
+				task.Finish(ret)
+			}()
+		}
+
+		// Branches that finish early close their channels and are skipped
+		ret := stream.Resolve(func(value Object) Object {
+			value = streamValue(stream, value)
+			active := false
+			for _, ch := range channels {
+				if !ch.IsClosed() && ch.Send(scope, value) == nil {
+					active = true
+				}
+			}
+
+			if !active {
+				return stream.Close(scope)
+			}
+			return nil
+		})
+		for _, ch := range channels {
+			if !ch.IsClosed() {
+				ch.Close(scope)
+			}
+		}
+		if isRaise(ret) {
+			return ret
+		}
+
+		results := make([]Object, len(tasks))
+		for i, task := range tasks {
+			maybe, err := task.Wait(scope)
+			if err != nil {
+				return err
+			}
+			if !maybe.Ok {
+				return scope.Interrupt(RaiseWith(maybe.Error.(*Error)))
+			}
+			results[i] = maybe.Value
+		}
+		return NewTuple(results...)
+	},
+	`fork`,
+	`Sends each element of the stream to all functions, each receiving its own stream, and returns their results as a tuple. The functions run side by side, so the stream is consumed only once.`,
+	P("stream"),
+	P("fns", V.Type(FunctionId)).AsSpread(),
+)
+
+var Broadcast = F(
+	func(scope *Scope, args ...Object) Object {
+		return Fork.Call(scope, args...)
+	},
+	`broadcast`,
+	`Alias of fork.`,
+	P("stream"),
+	P("fns", V.Type(FunctionId)).AsSpread(),
+)
+
+// teeState holds the elements pulled from the upstream that were not consumed
+// by all branches yet.
+type teeState struct {
+	upstream *Stream
+	buffer   []Object
+	offset   int   // position of the first element of the buffer
+	pos      []int // position of the next element of each branch
+	closed   []bool
+	finished bool
+}
+
+func (t *teeState) next(scope *Scope, i int) Object {
+	idx := t.pos[i] - t.offset
+	if idx >= len(t.buffer) {
+		if t.finished {
+			return nil
+		}
+
+		maybe := Stream_Next.Call(scope, t.upstream)
+		if isRaise(maybe) {
+			return maybe
+		}
+		if t.upstream.Finished {
+			t.finished = true
+			return nil
+		}
+		t.buffer = append(t.buffer, maybe.(*Maybe).Value)
+	}
+
+	value := t.buffer[idx]
+	t.pos[i]++
+	t.trim()
+	return YieldWith(value)
+}
+
+// Drops the elements consumed by all open branches.
+func (t *teeState) trim() {
+	least := -1
+	for i, pos := range t.pos {
+		if !t.closed[i] && (least < 0 || pos < least) {
+			least = pos
+		}
+	}
+	if least < 0 {
+		least = t.offset + len(t.buffer)
+	}
+
+	if drop := least - t.offset; drop > 0 {
+		clear(t.buffer[:drop])
+		t.buffer = t.buffer[drop:]
+		t.offset = least
+	}
+}
+
+// Closes the branch, closing the upstream when all branches are closed.
+func (t *teeState) close(scope *Scope, i int) Object {
+	t.closed[i] = true
+	t.trim()
+	for _, closed := range t.closed {
+		if !closed {
+			return nil
+		}
+	}
+	return t.upstream.Close(scope)
+}
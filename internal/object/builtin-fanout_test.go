@@ -0,0 +1,34 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestFunction_Tee(t *testing.T) {
+	common.AssertCode(t, ` a, b := range(5) | tee; [a | List, b | List]`, `[[0, 1, 2, 3, 4], [0, 1, 2, 3, 4]]`)
+	common.AssertCode(t, ` a, b, c := [1, 2] | tee 3; [a | List, b | List, c | List]`, `[[1, 2], [1, 2], [1, 2]]`)
+	common.AssertCode(t, ` a, b := range() | tee; x := a | take 3; y := b | skip 1; zip(x, y) | List`, `[(0, 1), (1, 2), (2, 3)]`)
+	common.AssertCode(t, ` a, b := range(3) | map x: x * 10 | tee; a.Next(); [b | List, a | List]`, `[[0, 10, 20], [10, 20]]`)
+	common.AssertCodeError(t, ` range(3) | tee 0`)
+}
+
+func TestFunction_Tee_Close(t *testing.T) {
+	// The upstream is only closed when all branches are closed
+	common.AssertCode(t, ` s := range(5); a, b := s | tee; a.Close(); b | List`, `[0, 1, 2, 3, 4]`)
+	common.AssertCode(t, ` s := range(5); a, b := s | tee; a.Close(); b.Close(); s.Finished()`, `true`)
+}
+
+func TestFunction_Fork(t *testing.T) {
+	common.AssertCode(t, ` range(10) | fork sum, count`, `(45, 10)`)
+	common.AssertCode(t, ` double := fn(s) { s | map x: x * 2 | List }; [1, 2, 3] | fork double, count`, `([2, 4, 6], 3)`)
+	common.AssertCode(t, ` range(1000) | fork sum, (s: s.Next().Value())`, `(499500, 0)`)
+	common.AssertCode(t, ` [] | fork count`, `(0)`)
+	common.AssertCodeError(t, ` boom := fn(s) { raise 'boom' }; [1, 2] | fork boom, count`)
+	common.AssertCodeError(t, ` [1, 2] | fork 1`)
+}
+
+func TestFunction_Broadcast(t *testing.T) {
+	common.AssertCode(t, ` [1, 2, 3] | broadcast sum, count`, `(6, 3)`)
+}
@@ -2,6 +2,8 @@ package object
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
 	"regexp"
 	"strings"
 )
@@ -114,6 +116,8 @@ var Range = F(
 		step := 1.
 
 		switch len(args) {
+		case 0:
+			end = math.Inf(1)
 		case 1:
 			end = args[0].(*Number).Value
 		case 2:
@@ -140,7 +144,7 @@ var Range = F(
 		}, scope)
 	},
 	`range`,
-	`Returns a range object.`,
+	`Returns a range object. Without arguments, the range counts from zero indefinitely.`,
 	P("values", V.Type(NumberId)).AsSpread(),
 )
 
@@ -152,8 +156,8 @@ var Filter = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[1].(*Function)
-		return NewInternalStream(func(s *Scope) Object {
+		f := args[1]
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
 			for {
 				maybe := Stream_Next.Call(s, stream)
 				if isRaise(maybe) {
@@ -172,7 +176,7 @@ var Filter = F(
 					return YieldWith(value)
 				}
 			}
-		}, scope)
+		}, scope))
 	},
 	`filter`,
 	`Filters the stream.`,
@@ -188,8 +192,8 @@ var Each = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[1].(*Function)
-		return NewInternalStream(func(s *Scope) Object {
+		f := args[1]
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
 			maybe := Stream_Next.Call(s, stream)
 			if isRaise(maybe) {
 				return maybe
@@ -204,7 +208,7 @@ var Each = F(
 				return ret
 			}
 			return YieldWith(value)
-		}, scope)
+		}, scope))
 	},
 	`each`,
 	`Iterates over the stream.`,
@@ -220,7 +224,7 @@ var Map = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[1].(*Function)
+		f := args[1]
 		return NewInternalStream(func(s *Scope) Object {
 			maybe := Stream_Next.Call(s, stream)
 			if isRaise(maybe) {
@@ -236,7 +240,7 @@ var Map = F(
 				return ret
 			}
 			return YieldWith(ret)
-		}, scope)
+		}, scope).WithUpstream(stream)
 	},
 	`map`,
 	`Maps the stream.`,
@@ -252,7 +256,7 @@ var Reduce = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[2].(*Function)
+		f := args[2]
 		acc := args[1]
 		for {
 			maybe := Stream_Next.Call(scope, stream)
@@ -266,7 +270,7 @@ var Reduce = F(
 			value := maybe.(*Maybe).Value
 			acc = scope.Eval().Call(scope, f, toLambdaParams(acc, value))
 			if isRaise(acc) {
-				return acc
+				return stream.CloseWith(scope, acc)
 			}
 		}
 	},
@@ -302,7 +306,7 @@ var Sum = F(
 
 			number, ok := value.(*Number)
 			if !ok {
-				return scope.Interrupt(Raise("expected number, got %s", value.Type()))
+				return stream.CloseWith(scope, scope.Interrupt(Raise("expected number, got %s", value.Type())))
 			}
 			sum += number.Value
 		}
@@ -320,7 +324,7 @@ var SumBy = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[1].(*Function)
+		f := args[1]
 		sum := 0.
 		for {
 			maybe := Stream_Next.Call(scope, stream)
@@ -334,12 +338,12 @@ var SumBy = F(
 			value := maybe.(*Maybe).Value
 			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
 			if isRaise(ret) {
-				return ret
+				return stream.CloseWith(scope, ret)
 			}
 
 			number, ok := ret.(*Number)
 			if !ok {
-				return scope.Interrupt(Raise("expected number, got %s", value.Type()))
+				return stream.CloseWith(scope, scope.Interrupt(Raise("expected number, got %s", value.Type())))
 			}
 			sum += number.Value
 		}
@@ -384,7 +388,7 @@ var CountBy = F(
 		}
 		stream := s.(*Stream)
 
-		f := args[1].(*Function)
+		f := args[1]
 		count := 0
 		for {
 			maybe := Stream_Next.Call(scope, stream)
@@ -398,12 +402,12 @@ var CountBy = F(
 			value := maybe.(*Maybe).Value
 			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
 			if isRaise(ret) {
-				return ret
+				return stream.CloseWith(scope, ret)
 			}
 
 			number, ok := ret.(*Number)
 			if !ok {
-				return scope.Interrupt(Raise("expected number, got %s", value.Type()))
+				return stream.CloseWith(scope, scope.Interrupt(Raise("expected number, got %s", value.Type())))
 			}
 			count += int(number.Value)
 		}
@@ -414,6 +418,326 @@ var CountBy = F(
 	P("f", V.Type(FunctionId)),
 )
 
+var Take = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := int(args[1].(*Number).Value)
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			if n <= 0 {
+				return nil
+			}
+			n--
+
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope))
+	},
+	`take`,
+	`Takes the first n elements of the stream, closing it afterwards.`,
+	P("stream"),
+	P("n", V.Type(NumberId)),
+)
+
+var Skip = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := int(args[1].(*Number).Value)
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					return nil
+				}
+
+				if n > 0 {
+					n--
+					continue
+				}
+				return YieldWith(maybe.(*Maybe).Value)
+			}
+		}, scope))
+	},
+	`skip`,
+	`Skips the first n elements of the stream.`,
+	P("stream"),
+	P("n", V.Type(NumberId)),
+)
+
+var TakeWhile = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			value := maybe.(*Maybe).Value
+			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(ret) {
+				return ret
+			}
+			if !ret.AsBool() {
+				return nil
+			}
+			return YieldWith(value)
+		}, scope))
+	},
+	`takeWhile`,
+	`Takes the elements of the stream while the function returns true, closing it afterwards.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var DropWhile = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		dropping := true
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					return nil
+				}
+
+				value := maybe.(*Maybe).Value
+				if dropping {
+					ret := scope.Eval().Call(scope, f, toLambdaParams(value))
+					if isRaise(ret) {
+						return ret
+					}
+					if ret.AsBool() {
+						continue
+					}
+					dropping = false
+				}
+				return YieldWith(value)
+			}
+		}, scope))
+	},
+	`dropWhile`,
+	`Skips the elements of the stream while the function returns true.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var First = F(
+	func(scope *Scope, args ...Object) Object {
+		return Nth.Call(scope, args[0], Zero)
+	},
+	`first`,
+	`Returns the first element of the stream as a Maybe, closing the stream afterwards.`,
+	P("stream"),
+)
+
+var Last = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		var last Object
+		for {
+			maybe := Stream_Next.Call(scope, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				break
+			}
+			last = maybe.(*Maybe).Value
+		}
+
+		if last == nil {
+			return NewMaybe(NewErrorFromString("stream is empty"))
+		}
+		return NewMaybe(streamValue(stream, last))
+	},
+	`last`,
+	`Returns the last element of the stream as a Maybe.`,
+	P("stream"),
+)
+
+var Nth = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := int(args[1].(*Number).Value)
+		if n < 0 {
+			return stream.CloseWith(scope, scope.Interrupt(Raise("index must be non-negative, got %d", n)))
+		}
+
+		for i := 0; ; i++ {
+			maybe := Stream_Next.Call(scope, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return NewMaybe(NewErrorFromString(fmt.Sprintf("stream has no element at index %d", n)))
+			}
+
+			if i == n {
+				value := maybe.(*Maybe).Value
+				return stream.CloseWith(scope, NewMaybe(streamValue(stream, value)))
+			}
+		}
+	},
+	`nth`,
+	`Returns the element at the given index of the stream as a Maybe, closing the stream afterwards.`,
+	P("stream"),
+	P("n", V.Type(NumberId)),
+)
+
+var Find = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		for {
+			maybe := Stream_Next.Call(scope, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return NewMaybe(NewErrorFromString("element not found"))
+			}
+
+			value := maybe.(*Maybe).Value
+			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(ret) {
+				return stream.CloseWith(scope, ret)
+			}
+			if ret.AsBool() {
+				return stream.CloseWith(scope, NewMaybe(streamValue(stream, value)))
+			}
+		}
+	},
+	`find`,
+	`Returns the first element of the stream for which the function returns true, as a Maybe. Closes the stream afterwards.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Any = F(
+	func(scope *Scope, args ...Object) Object {
+		return matchStream(scope, args, true)
+	},
+	`any`,
+	`Returns true if the function returns true for any element of the stream, closing it as soon as one is found. Without a function, checks the elements themselves.`,
+	P("stream"),
+	P("f"),
+)
+
+var All = F(
+	func(scope *Scope, args ...Object) Object {
+		return matchStream(scope, args, false)
+	},
+	`all`,
+	`Returns true if the function returns true for all elements of the stream, closing it as soon as one fails. Without a function, checks the elements themselves.`,
+	P("stream"),
+	P("f"),
+)
+
+// Resolves any and all, stopping at the first element whose check is equal
+// to `stopAt`.
+func matchStream(scope *Scope, args []Object, stopAt bool) Object {
+	s := StreamTypeObj.Convert(scope, args[0])
+	if isRaise(s) {
+		return s
+	}
+	stream := s.(*Stream)
+
+	var f Object
+	if len(args) > 1 {
+		f = args[1]
+	}
+
+	for {
+		maybe := Stream_Next.Call(scope, stream)
+		if isRaise(maybe) {
+			return maybe
+		}
+		if stream.Finished {
+			return NewBoolean(!stopAt)
+		}
+
+		var ret Object = streamValue(stream, maybe.(*Maybe).Value)
+		if f != nil {
+			ret = scope.Eval().Call(scope, f, toLambdaParams(maybe.(*Maybe).Value))
+			if isRaise(ret) {
+				return stream.CloseWith(scope, ret)
+			}
+		}
+
+		if ret.AsBool() == stopAt {
+			return stream.CloseWith(scope, NewBoolean(stopAt))
+		}
+	}
+}
+
+var Hash = F(
+	func(scope *Scope, args ...Object) Object {
+		key := HashKey(scope, args[0])
+		if isRaise(key) {
+			return key
+		}
+
+		h := fnv.New32a()
+		h.Write([]byte(key.AsString()))
+		return NewNumber(float64(h.Sum32()))
+	},
+	`hash`,
+	`Returns the hash of the value. Structurally equal values have the same hash.`,
+	P("value"),
+)
+
 func toLambdaParams(values ...Object) []Object {
 	params := []Object{}
 
@@ -430,3 +754,18 @@ func toLambdaParams(values ...Object) []Object {
 
 	return params
 }
+
+// Streams over collections yield `(value, index)` tuples. Functions returning
+// a single element of the stream keep only the value.
+func streamValue(stream *Stream, value Object) Object {
+	if t, ok := value.(*Tuple); ok && stream.Indexed && len(t.Elements) > 0 {
+		return t.Elements[0]
+	}
+	return value
+}
+
+// Stages that yield the elements of the upstream as they are keep its indices.
+func passThrough(upstream, stream *Stream) *Stream {
+	stream.Indexed = upstream.Indexed
+	return stream.WithUpstream(upstream)
+}
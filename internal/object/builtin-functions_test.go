@@ -16,10 +16,12 @@ func TestFunction_Each(t *testing.T) {
 
 func TestFunction_Map(t *testing.T) {
 	common.AssertCode(t, ` [1,2,3] | map x: x*2 | List`, `[2, 4, 6]`)
+	common.AssertCode(t, ` [4,9] | map Math.Sqrt | List`, `[2, 3]`)
 }
 
 func TestFunction_Reduce(t *testing.T) {
 	common.AssertCode(t, ` [1,2,3] | reduce 0, (acc, x): acc + x`, `6`)
+	common.AssertCode(t, ` [4,3] | reduce 0, Math.Hypot`, `5`)
 	// common.AssertCode(t, ` [1,2,3] | reduce 0, Number.Add`, `6`)
 }
 
@@ -38,3 +40,87 @@ func TestFunction_Count(t *testing.T) {
 func TestFunction_CountBy(t *testing.T) {
 	common.AssertCode(t, ` [1,2,3] | countBy x: 2`, `6`)
 }
+
+func TestFunction_Hash(t *testing.T) {
+	common.AssertCode(t, `hash([1, 'a']) == hash([1, 'a'])`, `true`)
+	common.AssertCode(t, `hash({a=1, b=2}) == hash({b=2, a=1})`, `true`)
+	common.AssertCode(t, `hash([1, 2]) == hash([2, 1])`, `false`)
+}
+
+func TestFunction_Range(t *testing.T) {
+	common.AssertCode(t, ` range(3) | List`, `[0, 1, 2]`)
+	common.AssertCode(t, ` range() | take 3 | List`, `[0, 1, 2]`)
+}
+
+func TestFunction_Take(t *testing.T) {
+	common.AssertCode(t, ` range() | take 5 | List`, `[0, 1, 2, 3, 4]`)
+	common.AssertCode(t, ` [1,2] | take 5 | List`, `[1, 2]`)
+	common.AssertCode(t, ` [1,2] | take 0 | List`, `[]`)
+}
+
+func TestFunction_Skip(t *testing.T) {
+	common.AssertCode(t, ` range(10) | skip 7 | List`, `[7, 8, 9]`)
+	common.AssertCode(t, ` range() | skip 2 | take 2 | List`, `[2, 3]`)
+}
+
+func TestFunction_TakeWhile(t *testing.T) {
+	common.AssertCode(t, ` range() | takeWhile x: x < 4 | List`, `[0, 1, 2, 3]`)
+	common.AssertCode(t, ` [5,1,2] | takeWhile x: x < 4 | List`, `[]`)
+}
+
+func TestFunction_DropWhile(t *testing.T) {
+	common.AssertCode(t, ` [1,5,2,6] | dropWhile x: x < 4 | List`, `[5, 2, 6]`)
+}
+
+func TestFunction_First(t *testing.T) {
+	common.AssertCode(t, ` (range(5, 10) | first).Value()`, `5`)
+	common.AssertCode(t, ` ([3,4] | first).Value()`, `3`)
+	common.AssertCode(t, ` ([] | first).Ok()`, `false`)
+	common.AssertCode(t, ` fn g() { yield (1, 2) }; (g() | first).Value()`, `(1, 2)`)
+	common.AssertCode(t, ` fn g() { yield (1, 2) }; (g() | take 1 | last).Value()`, `(1, 2)`)
+}
+
+func TestFunction_Last(t *testing.T) {
+	common.AssertCode(t, ` ([3,4] | last).Value()`, `4`)
+	common.AssertCode(t, ` ([] | last).Ok()`, `false`)
+}
+
+func TestFunction_Nth(t *testing.T) {
+	common.AssertCode(t, ` (range() | nth 3).Value()`, `3`)
+	common.AssertCode(t, ` ([1] | nth 3).Ok()`, `false`)
+	common.AssertCodeError(t, ` [1] | nth -1`)
+}
+
+func TestFunction_Find(t *testing.T) {
+	common.AssertCode(t, ` (range() | find x: x * x > 50).Value()`, `8`)
+	common.AssertCode(t, ` ([3,4,5] | find (x, i): i == 2).Value()`, `5`)
+	common.AssertCode(t, ` ([3,4,5] | find x: x > 9).Ok()`, `false`)
+}
+
+func TestFunction_Any(t *testing.T) {
+	common.AssertCode(t, ` range() | any x: x > 3`, `true`)
+	common.AssertCode(t, ` [1,2] | any x: x > 3`, `false`)
+	common.AssertCode(t, ` [false, true] | any`, `true`)
+}
+
+func TestFunction_All(t *testing.T) {
+	common.AssertCode(t, ` range() | all x: x < 3`, `false`)
+	common.AssertCode(t, ` [1,2] | all x: x < 3`, `true`)
+	common.AssertCode(t, ` [true, false] | all`, `false`)
+}
+
+func TestFunction_ClosesUpstream(t *testing.T) {
+	gen := `
+	log := []
+	fn Numbers() {
+		defer log.Push('closed')
+		i := 0
+		for { yield i; i += 1 }
+	}
+	`
+	common.AssertCode(t, gen+`Numbers() | take 2 | List; log`, `['closed']`)
+	common.AssertCode(t, gen+`Numbers() | takeWhile x: x < 2 | List; log`, `['closed']`)
+	common.AssertCode(t, gen+`Numbers() | first; log`, `['closed']`)
+	common.AssertCode(t, gen+`Numbers() | find x: x > 2; log`, `['closed']`)
+	common.AssertCode(t, gen+`Numbers() | any x: x > 2; log`, `['closed']`)
+}
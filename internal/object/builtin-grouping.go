@@ -0,0 +1,472 @@
+package object
+
+import "slices"
+
+var Chunk = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := int(args[1].(*Number).Value)
+		if n <= 0 {
+			return scope.Interrupt(Raise("chunk size must be positive, got %d", n))
+		}
+
+		return NewInternalStream(func(s *Scope) Object {
+			chunk := []Object{}
+			for len(chunk) < n {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					break
+				}
+				chunk = append(chunk, streamValue(stream, maybe.(*Maybe).Value))
+			}
+
+			if len(chunk) == 0 {
+				return nil
+			}
+			return YieldWith(NewList(chunk...))
+		}, scope).WithUpstream(stream)
+	},
+	`chunk`,
+	`Splits the stream into lists of n elements. The last list may be shorter.`,
+	P("stream"),
+	P("n", V.Type(NumberId)),
+)
+
+var Window = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		n := int(args[1].(*Number).Value)
+		step := 1
+		if len(args) > 2 {
+			step = int(args[2].(*Number).Value)
+		}
+		if n <= 0 || step <= 0 {
+			return scope.Interrupt(Raise("window size and step must be positive, got %d and %d", n, step))
+		}
+
+		window := []Object{}
+		skip := 0
+		return NewInternalStream(func(s *Scope) Object {
+			for len(window) < n {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					return nil
+				}
+
+				// Steps larger than the window drop the elements in between
+				if skip > 0 {
+					skip--
+					continue
+				}
+				window = append(window, streamValue(stream, maybe.(*Maybe).Value))
+			}
+
+			ret := NewList(slices.Clone(window)...)
+			if step < n {
+				window = window[step:]
+			} else {
+				window = window[:0]
+				skip = step - n
+			}
+			return YieldWith(ret)
+		}, scope).WithUpstream(stream)
+	},
+	`window`,
+	`Yields sliding windows of n elements as lists, moving step elements at a time (1 by default). Only full windows are yielded.`,
+	P("stream"),
+	P("n", V.Type(NumberId)),
+	P("step"),
+)
+
+var GroupBy = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		groups := NewDict(map[string]Object{})
+		ret := stream.Resolve(func(value Object) Object {
+			key := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(key) {
+				return key
+			}
+
+			hash := HashKey(scope, key)
+			if isRaise(hash) {
+				return hash
+			}
+
+			group, ok := groups.Elements[hash.AsString()].(*List)
+			if !ok {
+				group = NewList()
+				groups.SetKey(hash.AsString(), key, group)
+			}
+			group.Elements = append(group.Elements, streamValue(stream, value))
+			return nil
+		})
+		if isRaise(ret) {
+			return ret
+		}
+		return groups
+	},
+	`groupBy`,
+	`Groups the elements of the stream into a dict of lists, by the key returned by the function.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var GroupRuns = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		var key, next Object
+		var group []Object
+		return NewInternalStream(func(s *Scope) Object {
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					if len(group) == 0 {
+						return nil
+					}
+					ret := NewTuple(NewList(group...), key)
+					group = nil
+					return YieldWith(ret)
+				}
+
+				value := maybe.(*Maybe).Value
+				next = scope.Eval().Call(scope, f, toLambdaParams(value))
+				if isRaise(next) {
+					return next
+				}
+
+				if len(group) > 0 {
+					equals := Equals(scope, key, next)
+					if isRaise(equals) {
+						return equals
+					}
+
+					if !equals.AsBool() {
+						ret := NewTuple(NewList(group...), key)
+						group = []Object{streamValue(stream, value)}
+						key = next
+						return YieldWith(ret)
+					}
+				}
+
+				key = next
+				group = append(group, streamValue(stream, value))
+			}
+		}, scope).WithUpstream(stream)
+	},
+	`groupRuns`,
+	`Groups consecutive elements of the stream with the same key, yielding (group, key) tuples.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Partition = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		matched := NewList()
+		unmatched := NewList()
+		ret := stream.Resolve(func(value Object) Object {
+			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(ret) {
+				return ret
+			}
+
+			if ret.AsBool() {
+				matched.Elements = append(matched.Elements, streamValue(stream, value))
+			} else {
+				unmatched.Elements = append(unmatched.Elements, streamValue(stream, value))
+			}
+			return nil
+		})
+		if isRaise(ret) {
+			return ret
+		}
+		return NewTuple(matched, unmatched)
+	},
+	`partition`,
+	`Splits the stream into a tuple of two lists: the elements for which the function returns true, and the others.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Scan = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		acc := args[1]
+		f := args[2]
+		return NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			value := maybe.(*Maybe).Value
+			acc = scope.Eval().Call(scope, f, toLambdaParams(acc, value))
+			if isRaise(acc) {
+				return acc
+			}
+			return YieldWith(acc)
+		}, scope).WithUpstream(stream)
+	},
+	`scan`,
+	`Like reduce, but yields every intermediate value of the accumulator.`,
+	P("stream"),
+	P("acc"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Fold = F(
+	func(scope *Scope, args ...Object) Object {
+		return Reduce.Call(scope, args...)
+	},
+	`fold`,
+	`Alias of reduce.`,
+	P("stream"),
+	P("acc"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Distinct = F(
+	func(scope *Scope, args ...Object) Object {
+		return distinctStream(scope, args[0], nil)
+	},
+	`distinct`,
+	`Yields the elements of the stream that were not seen before.`,
+	P("stream"),
+)
+
+var DistinctBy = F(
+	func(scope *Scope, args ...Object) Object {
+		return distinctStream(scope, args[0], args[1])
+	},
+	`distinctBy`,
+	`Yields the elements of the stream whose key, returned by the function, was not seen before.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Min = F(
+	func(scope *Scope, args ...Object) Object {
+		return extremeOf(scope, args[0], nil, -1)
+	},
+	`min`,
+	`Returns the smallest element of the stream as a Maybe.`,
+	P("stream"),
+)
+
+var Max = F(
+	func(scope *Scope, args ...Object) Object {
+		return extremeOf(scope, args[0], nil, 1)
+	},
+	`max`,
+	`Returns the largest element of the stream as a Maybe.`,
+	P("stream"),
+)
+
+var MinBy = F(
+	func(scope *Scope, args ...Object) Object {
+		return extremeOf(scope, args[0], args[1], -1)
+	},
+	`minBy`,
+	`Returns the element of the stream with the smallest key, returned by the function, as a Maybe.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var MaxBy = F(
+	func(scope *Scope, args ...Object) Object {
+		return extremeOf(scope, args[0], args[1], 1)
+	},
+	`maxBy`,
+	`Returns the element of the stream with the largest key, returned by the function, as a Maybe.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var SortBy = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		values := []Object{}
+		keys := []Object{}
+		ret := stream.Resolve(func(value Object) Object {
+			key := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(key) {
+				return key
+			}
+
+			values = append(values, streamValue(stream, value))
+			keys = append(keys, key)
+			return nil
+		})
+		if isRaise(ret) {
+			return ret
+		}
+
+		indices := make([]int, len(values))
+		for i := range indices {
+			indices[i] = i
+		}
+
+		var err Object
+		slices.SortStableFunc(indices, func(a, b int) int {
+			if err != nil {
+				return 0
+			}
+
+			cmp := Compare(scope, keys[a], keys[b])
+			if isRaise(cmp) {
+				err = cmp
+				return 0
+			}
+			return int(cmp.(*Number).Value)
+		})
+		if err != nil {
+			return err
+		}
+
+		result := make([]Object, len(values))
+		for i, idx := range indices {
+			result[i] = values[idx]
+		}
+		return NewList(result...)
+	},
+	`sortBy`,
+	`Sorts the elements of the stream by the key returned by the function, keeping the order of equal keys. Returns a list.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+func distinctStream(scope *Scope, obj Object, f Object) Object {
+	s := StreamTypeObj.Convert(scope, obj)
+	if isRaise(s) {
+		return s
+	}
+	stream := s.(*Stream)
+
+	seen := map[string]bool{}
+	return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+		for {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			value := maybe.(*Maybe).Value
+			key := streamValue(stream, value)
+			if f != nil {
+				key = scope.Eval().Call(scope, f, toLambdaParams(value))
+				if isRaise(key) {
+					return key
+				}
+			}
+
+			hash := HashKey(scope, key)
+			if isRaise(hash) {
+				return hash
+			}
+
+			if !seen[hash.AsString()] {
+				seen[hash.AsString()] = true
+				return YieldWith(value)
+			}
+		}
+	}, scope))
+}
+
+// Returns the element with the smallest (sign -1) or largest (sign 1) key.
+func extremeOf(scope *Scope, obj Object, f Object, sign int) Object {
+	s := StreamTypeObj.Convert(scope, obj)
+	if isRaise(s) {
+		return s
+	}
+	stream := s.(*Stream)
+
+	var best, bestKey Object
+	ret := stream.Resolve(func(raw Object) Object {
+		value := streamValue(stream, raw)
+		key := value
+		if f != nil {
+			key = scope.Eval().Call(scope, f, toLambdaParams(raw))
+			if isRaise(key) {
+				return key
+			}
+		}
+
+		if best == nil {
+			best, bestKey = value, key
+			return nil
+		}
+
+		cmp := Compare(scope, key, bestKey)
+		if isRaise(cmp) {
+			return cmp
+		}
+
+		if int(cmp.(*Number).Value)*sign > 0 {
+			best, bestKey = value, key
+		}
+		return nil
+	})
+	if isRaise(ret) {
+		return ret
+	}
+
+	if best == nil {
+		return NewMaybe(NewErrorFromString("stream is empty"))
+	}
+	return NewMaybe(best)
+}
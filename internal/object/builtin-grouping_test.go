@@ -0,0 +1,62 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestFunction_Chunk(t *testing.T) {
+	common.AssertCode(t, ` range(7) | chunk 3 | List`, `[[0, 1, 2], [3, 4, 5], [6]]`)
+	common.AssertCode(t, ` range() | chunk 2 | first`, `Maybe(List, ok)`)
+	common.AssertCodeError(t, ` range(7) | chunk 0`)
+}
+
+func TestFunction_Window(t *testing.T) {
+	common.AssertCode(t, ` range(5) | window 3 | List`, `[[0, 1, 2], [1, 2, 3], [2, 3, 4]]`)
+	common.AssertCode(t, ` range(7) | window 3, 2 | List`, `[[0, 1, 2], [2, 3, 4], [4, 5, 6]]`)
+	common.AssertCode(t, ` range(7) | window 2, 3 | List`, `[[0, 1], [3, 4]]`)
+	common.AssertCode(t, ` [1] | window 2 | List`, `[]`)
+}
+
+func TestFunction_GroupBy(t *testing.T) {
+	common.AssertCode(t, ` d := range(6) | groupBy x: x % 2; [d[0], d[1]]`, `[[0, 2, 4], [1, 3, 5]]`)
+	common.AssertCode(t, ` d := ['a', 'bb', 'c'] | groupBy x: x.Size(); d[1]`, `['a', 'c']`)
+}
+
+func TestFunction_GroupRuns(t *testing.T) {
+	common.AssertCode(t, ` ['a','a','b','a'] | groupRuns x: x | List`, `[(['a', 'a'], 'a'), (['b'], 'b'), (['a'], 'a')]`)
+	common.AssertCode(t, ` [] | groupRuns x: x | List`, `[]`)
+}
+
+func TestFunction_Partition(t *testing.T) {
+	common.AssertCode(t, ` a, b := range(5) | partition x: x > 2; [a, b]`, `[[3, 4], [0, 1, 2]]`)
+}
+
+func TestFunction_Scan(t *testing.T) {
+	common.AssertCode(t, ` [1,2,3] | scan 0, (acc, x): acc + x | List`, `[1, 3, 6]`)
+	common.AssertCode(t, ` range() | scan 0, (acc, x): acc + x | take 4 | List`, `[0, 1, 3, 6]`)
+}
+
+func TestFunction_Fold(t *testing.T) {
+	common.AssertCode(t, ` [1,2,3] | fold 0, (acc, x): acc + x`, `6`)
+}
+
+func TestFunction_Distinct(t *testing.T) {
+	common.AssertCode(t, ` [1,2,1,[1],[1]] | distinct | List`, `[1, 2, [1]]`)
+	common.AssertCode(t, ` ['aa','b','cc'] | distinctBy x: x.Size() | List`, `['aa', 'b']`)
+}
+
+func TestFunction_MinMax(t *testing.T) {
+	common.AssertCode(t, ` ([3,1,2] | min).Value()`, `1`)
+	common.AssertCode(t, ` ([3,1,2] | max).Value()`, `3`)
+	common.AssertCode(t, ` (['aaa','b','cc'] | minBy x: x.Size()).Value()`, `b`)
+	common.AssertCode(t, ` (['aaa','b','cc'] | maxBy x: x.Size()).Value()`, `aaa`)
+	common.AssertCode(t, ` ([] | max).Ok()`, `false`)
+	common.AssertCodeError(t, ` [1, 'a'] | max`)
+}
+
+func TestFunction_SortBy(t *testing.T) {
+	common.AssertCode(t, ` ['bb','a','cc','d'] | sortBy x: x.Size()`, `['a', 'd', 'bb', 'cc']`)
+	common.AssertCode(t, ` [(2, 'a'), (1, 'b')] | sortBy (x, i): x`, `[(1, 'b'), (2, 'a')]`)
+}
@@ -0,0 +1,148 @@
+package object
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// progressWidth is the number of characters of the progress bar.
+const progressWidth = 30
+
+// progressRefresh is the minimum time between two renders of the progress.
+const progressRefresh = 100 * time.Millisecond
+
+var Tap = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		f := args[1]
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			value := maybe.(*Maybe).Value
+			ret := scope.Eval().Call(scope, f, toLambdaParams(value))
+			if isRaise(ret) {
+				return ret
+			}
+			return YieldWith(value)
+		}, scope))
+	},
+	`tap`,
+	`Calls the function with each element for its side effects, like printing, yielding the elements unchanged.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+)
+
+var Progress = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		p := &progressBar{
+			w:     scope.Stderr(),
+			clock: scope.Clock(),
+		}
+		if len(args) > 1 {
+			total, ok := args[1].(*Number)
+			if !ok || total.Value < 0 {
+				return scope.Interrupt(Raise("progress total must be a non-negative number, got '%s'", args[1].AsString()))
+			}
+			p.total = int(total.Value)
+		}
+		if len(args) > 2 {
+			p.label = args[2].AsString()
+		}
+
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			if p.start.IsZero() {
+				p.start = p.clock.Now()
+			}
+
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			p.count++
+			p.render(false)
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope)).OnClose(func(s *Scope) Object {
+			p.render(true)
+			return nil
+		})
+	},
+	`progress`,
+	`Reports the number of elements passing through and their rate to stderr, yielding the elements unchanged. With a total, renders a progress bar. The label is shown before the progress.`,
+	P("stream"),
+	P("total"),
+	P("label"),
+)
+
+// progressBar renders the progress in a single line, rewritten in place.
+type progressBar struct {
+	w        io.Writer
+	clock    Clock
+	total    int // 0 if unknown
+	label    string
+	count    int
+	start    time.Time
+	rendered time.Time
+	done     bool
+}
+
+// Renders the progress, at most once per refresh period. The final render
+// always happens and ends the line.
+func (p *progressBar) render(final bool) {
+	if p.done {
+		return
+	}
+
+	now := p.clock.Now()
+	if !final && p.count > 1 && now.Sub(p.rendered) < progressRefresh {
+		return
+	}
+	p.rendered = now
+	p.done = final
+
+	rate := 0.0
+	if elapsed := now.Sub(p.start); !p.start.IsZero() && elapsed > 0 {
+		rate = float64(p.count) / elapsed.Seconds()
+	}
+
+	line := &strings.Builder{}
+	line.WriteString("\r")
+	if p.label != "" {
+		line.WriteString(p.label + " ")
+	}
+	if p.total > 0 {
+		ratio := min(float64(p.count)/float64(p.total), 1)
+		filled := int(ratio * progressWidth)
+		fmt.Fprintf(line, "[%s%s] %3.0f%% %d/%d", strings.Repeat("#", filled), strings.Repeat("-", progressWidth-filled), ratio*100, p.count, p.total)
+	} else {
+		fmt.Fprintf(line, "%d", p.count)
+	}
+	fmt.Fprintf(line, " %.1f/s", rate)
+	if final {
+		line.WriteString("\n")
+	}
+
+	io.WriteString(p.w, line.String())
+}
@@ -0,0 +1,56 @@
+package object_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/internal/object"
+	"github.com/renatopp/pipelang/internal/runtime"
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func runWithStderr(t *testing.T, program string) (string, object.Object) {
+	r := runtime.New()
+	r.SetClock(object.NewFakeClock(time.Unix(0, 0)))
+	stderr := &strings.Builder{}
+	r.SetStderr(stderr)
+
+	obj, err := r.RunCode([]byte(program))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return stderr.String(), obj
+}
+
+func TestFunction_Tap(t *testing.T) {
+	common.AssertCode(t, ` total := 0; [1, 2, 3] | tap (x: total = total + x) | List`, `[1, 2, 3]`)
+	common.AssertCode(t, ` total := 0; [1, 2, 3] | tap (x: total = total + x) | List; total`, `6`)
+	common.AssertCode(t, ` total := 0; [1, 2, 3] | tap (x: total = total + x) | take 2 | List; total`, `3`)
+	common.AssertCodeError(t, ` [1, 2] | tap (x: raise 'x') | List`)
+}
+
+func TestFunction_Progress(t *testing.T) {
+	stderr, obj := runWithStderr(t, ` range(3) | progress | List`)
+	if obj.AsString() != `[0, 1, 2]` {
+		t.Errorf("expected [0, 1, 2], got %s", obj.AsString())
+	}
+	if !strings.HasSuffix(stderr, "\r3 0.0/s\n") {
+		t.Errorf("unexpected progress %q", stderr)
+	}
+
+	slow := ` slow := fn(x) { sleep(100); return x };`
+	stderr, _ = runWithStderr(t, slow+` range(4) | map slow | progress 4, 'items' | List`)
+	if !strings.HasSuffix(stderr, "\ritems [##############################] 100% 4/4 10.0/s\n") {
+		t.Errorf("unexpected progress %q", stderr)
+	}
+	if !strings.Contains(stderr, "\ritems [###############---------------]  50% 2/4 10.0/s") {
+		t.Errorf("unexpected progress %q", stderr)
+	}
+
+	stderr, _ = runWithStderr(t, ` range(10) | progress 10 | take 2 | List`)
+	if !strings.HasSuffix(stderr, "2/10 0.0/s\n") {
+		t.Errorf("unexpected progress %q", stderr)
+	}
+	common.AssertCodeError(t, ` [1] | progress 'a' | List`)
+}
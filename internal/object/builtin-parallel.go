@@ -0,0 +1,195 @@
+package object
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// ----------------------------------------------------------------------------
+// Parallel functions call the function for several elements at the same time,
+// each call in its own goroutine and scope. The calls share the interpreter
+// lock, so they only run concurrently while waiting, for instance, sleeping or
+// calling external processes.
+// ----------------------------------------------------------------------------
+
+var PMap = F(
+	func(scope *Scope, args ...Object) Object {
+		return parallelStream(scope, args, false, func(value, ret Object) (Object, bool) {
+			return ret, true
+		})
+	},
+	`pmap`,
+	`Like map, but calls the function in parallel by a number of workers (the number of CPUs by default). The input order is kept, unless ordered is false.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+	P("workers"),
+	P("ordered"),
+)
+
+var PFilter = F(
+	func(scope *Scope, args ...Object) Object {
+		return parallelStream(scope, args, true, func(value, ret Object) (Object, bool) {
+			return value, ret.AsBool()
+		})
+	},
+	`pfilter`,
+	`Like filter, but calls the function in parallel by a number of workers (the number of CPUs by default). The input order is kept, unless ordered is false.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+	P("workers"),
+	P("ordered"),
+)
+
+var PEach = F(
+	func(scope *Scope, args ...Object) Object {
+		return parallelStream(scope, args, true, func(value, ret Object) (Object, bool) {
+			return value, true
+		})
+	},
+	`peach`,
+	`Like each, but calls the function in parallel by a number of workers (the number of CPUs by default). The input order is kept, unless ordered is false.`,
+	P("stream"),
+	P("f", V.Type(FunctionId)),
+	P("workers"),
+	P("ordered"),
+)
+
+type parallelJob struct {
+	idx   int
+	value Object
+}
+
+type parallelResult struct {
+	idx   int
+	value Object
+	ret   Object
+}
+
+// Runs the function over the stream with a number of workers. The upstream is
+// only pulled by the consumer, keeping at most one element per worker in
+// flight, or waiting to be yielded in order. Each element gets its own
+// goroutine, which finishes with the call, so no goroutine is left waiting
+// when the stream is closed or abandoned. The first raise stops the stream
+// and is returned. Stopping the stream cancels the calls still running, which
+// raise at their next call or wait. If keepValues is true, fn returns the elements of the
+// upstream, keeping their indices.
+func parallelStream(scope *Scope, args []Object, keepValues bool, fn func(value, ret Object) (Object, bool)) Object {
+	s := StreamTypeObj.Convert(scope, args[0])
+	if isRaise(s) {
+		return s
+	}
+	stream := s.(*Stream)
+
+	f := args[1]
+	workers := runtime.NumCPU()
+	if len(args) > 2 {
+		n, ok := args[2].(*Number)
+		if !ok || n.Value < 1 {
+			return scope.Interrupt(Raise("the number of workers must be a positive number, got '%s'", args[2].AsString()))
+		}
+		workers = int(n.Value)
+	}
+
+	ordered := true
+	if len(args) > 3 {
+		ordered = args[3].AsBool()
+	}
+
+	// Buffered for every element in flight, so the calls never block on it
+	results := make(chan parallelResult, workers)
+	cancel := make(chan struct{})
+	stopped := false
+	stop := func() {
+		if !stopped {
+			stopped = true
+			close(cancel)
+		}
+	}
+
+	work := func(job parallelJob) {
+		ws := scope.New().WithCancel(cancel)
+		ws.Acquire()
+		ret := ws.Eval().Call(ws, f, toLambdaParams(job.value))
+		ws.Release()
+		results <- parallelResult{idx: job.idx, value: job.value, ret: ret}
+	}
+
+	sent := 0
+	inFlight := 0
+	next := 0 // index of the next result to yield, when ordered
+	pending := map[int]parallelResult{}
+	busy := func() int {
+		if ordered {
+			return sent - next
+		}
+		return inFlight
+	}
+
+	out := NewInternalStream(func(s *Scope) Object {
+		for {
+			// Keeps the workers busy, without reading ahead of them
+			for !stopped && busy() < workers && !stream.Finished {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					stop()
+					return maybe
+				}
+				if stream.Finished {
+					break
+				}
+
+				go work(parallelJob{idx: sent, value: maybe.(*Maybe).Value})
+				sent++
+				inFlight++
+			}
+
+			if ordered {
+				if result, ok := pending[next]; ok {
+					delete(pending, next)
+					next++
+					if value, ok := fn(result.value, result.ret); ok {
+						return YieldWith(value)
+					}
+					continue
+				}
+			}
+
+			if inFlight == 0 {
+				stop()
+				return nil
+			}
+
+			_, received, _, err := s.waitPeers([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(results)},
+			})
+			if err != nil {
+				stop()
+				return err
+			}
+			result := received.Interface().(parallelResult)
+			inFlight--
+
+			if isRaise(result.ret) {
+				stop()
+				return result.ret
+			}
+
+			if ordered {
+				pending[result.idx] = result
+				continue
+			}
+
+			if value, ok := fn(result.value, result.ret); ok {
+				return YieldWith(value)
+			}
+		}
+	}, scope).WithUpstream(stream)
+
+	if keepValues {
+		out.Indexed = stream.Indexed
+	}
+	return out.OnClose(func(s *Scope) Object {
+		stop()
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package object_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestFunction_PMap(t *testing.T) {
+	common.AssertCode(t, ` range(10) | pmap (x: x * 2), 3 | List`, `[0, 2, 4, 6, 8, 10, 12, 14, 16, 18]`)
+	common.AssertCode(t, ` [1, 2, 3] | pmap x: x + 1 | List`, `[2, 3, 4]`)
+	common.AssertCode(t, ` range() | pmap (x: x), 4 | take 3 | List`, `[0, 1, 2]`)
+	common.AssertCode(t, ` slow := fn(x) { sleep(x); x }; [30, 10, 20] | pmap slow, 3 | List`, `[30, 10, 20]`)
+	common.AssertCode(t, ` slow := fn(x) { sleep(x); x }; [30, 10, 20] | pmap slow, 3, false | sortBy x: x`, `[10, 20, 30]`)
+	common.AssertCodeError(t, ` [1, 2, 3] | pmap x: raise 'boom' | List`)
+	common.AssertCodeError(t, ` [1, 2, 3] | pmap (x: x), 0`)
+}
+
+func TestFunction_PMap_Concurrent(t *testing.T) {
+	start := time.Now()
+	common.AssertCode(t, ` range(4) | pmap (x: sleep(50)), 4 | count`, `4`)
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected the workers to sleep concurrently, took %s", elapsed)
+	}
+}
+
+func TestFunction_PMap_Abandoned(t *testing.T) {
+	before := runtime.NumGoroutine()
+	common.AssertCode(t, ` s := range() | pmap (x: x), 4 | take 1; s.Next().Value()`, `0`)
+	common.AssertCode(t, ` range() | pmap (x: x), 4 | take 1 | List`, `[0]`)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected the workers to finish, %d goroutines left running", after-before)
+	}
+}
+
+func TestFunction_PMap_Cancel(t *testing.T) {
+	// The calls still running are cancelled by the first raise
+	common.AssertCode(t, ` seen := []; f := fn(x) { if x == 0 { raise 'boom' }; sleep(50); seen.Push(x) }; r := ([0, 1, 2] | peach f, 3 | List)?; sleep(100); (r.Ok(), seen)`, `(false, [])`)
+	common.AssertCode(t, ` c := Channel(); f := fn(x) { if x == 0 { raise 'boom' }; c.Receive() }; r := ([0, 1] | pmap f, 2 | List)?; r.Ok()`, `false`)
+	common.AssertCode(t, ` seen := []; f := fn(x) { sleep(x); seen.Push(x) }; [0, 50] | pmap f, 2 | take 1 | List; sleep(100); seen`, `[0]`)
+}
+
+func TestFunction_PFilter(t *testing.T) {
+	common.AssertCode(t, ` range(10) | pfilter (x: x % 2 == 0), 3 | List`, `[0, 2, 4, 6, 8]`)
+	common.AssertCode(t, ` ['a', 'bb'] | pfilter x: x.Size() > 1 | List`, `['bb']`)
+}
+
+func TestFunction_PEach(t *testing.T) {
+	common.AssertCode(t, ` total := 0; [1, 2, 3] | peach (x: total += x), 2 | List; total`, `6`)
+	common.AssertCode(t, ` [1, 2, 3] | peach x: x * 10 | List`, `[1, 2, 3]`)
+}
@@ -0,0 +1,573 @@
+package object
+
+import (
+	"slices"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Relational functions operate over streams of records, which are dicts or
+// data objects. Fields are given by name, or by a function receiving the
+// record.
+// ----------------------------------------------------------------------------
+
+var Join = F(
+	func(scope *Scope, args ...Object) Object {
+		return joinStreams(scope, args, false, false)
+	},
+	`join`,
+	`Yields (left, right) tuples for the records of both streams with equal keys. The right stream is read first. If the right key is omitted, the left key is used for both.`,
+	P("left"),
+	P("right"),
+	P("leftKey"),
+	P("rightKey"),
+)
+
+var LeftJoin = F(
+	func(scope *Scope, args ...Object) Object {
+		return joinStreams(scope, args, true, false)
+	},
+	`leftJoin`,
+	`Like join, but the right records are Maybes, and the left records without a match are yielded too, with a Maybe error.`,
+	P("left"),
+	P("right"),
+	P("leftKey"),
+	P("rightKey"),
+)
+
+var OuterJoin = F(
+	func(scope *Scope, args ...Object) Object {
+		return joinStreams(scope, args, true, true)
+	},
+	`outerJoin`,
+	`Like leftJoin, but both records are Maybes, and the right records without a match are yielded too, after the left stream finishes.`,
+	P("left"),
+	P("right"),
+	P("leftKey"),
+	P("rightKey"),
+)
+
+var OrderBy = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		// Fields prefixed with `-` are sorted in descending order
+		keys := args[1:]
+		desc := make([]bool, len(keys))
+		for i, key := range keys {
+			if str, ok := key.(*String); ok && strings.HasPrefix(str.Value, "-") {
+				keys[i] = NewString(str.Value[1:])
+				desc[i] = true
+			}
+		}
+
+		type row struct {
+			value Object
+			keys  []Object
+		}
+		rows := []row{}
+		ret := stream.Resolve(func(value Object) Object {
+			value = streamValue(stream, value)
+			r := row{value: value, keys: make([]Object, len(keys))}
+			for i, key := range keys {
+				v := fieldOf(scope, value, key)
+				if isRaise(v) {
+					return v
+				}
+				r.keys[i] = v
+			}
+			rows = append(rows, r)
+			return nil
+		})
+		if isRaise(ret) {
+			return ret
+		}
+
+		var err Object
+		slices.SortStableFunc(rows, func(a, b row) int {
+			for i := range keys {
+				if err != nil {
+					return 0
+				}
+
+				cmp := Compare(scope, a.keys[i], b.keys[i])
+				if isRaise(cmp) {
+					err = cmp
+					return 0
+				}
+
+				if v := int(cmp.(*Number).Value); v != 0 {
+					if desc[i] {
+						return -v
+					}
+					return v
+				}
+			}
+			return 0
+		})
+		if err != nil {
+			return err
+		}
+
+		result := make([]Object, len(rows))
+		for i, r := range rows {
+			result[i] = r.value
+		}
+		return NewList(result...)
+	},
+	`orderBy`,
+	`Sorts the records by the given fields or functions, returning a list. Fields prefixed with '-' are sorted in descending order.`,
+	P("stream"),
+	P("keys").AsSpread(),
+)
+
+var Project = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		fields := args[1:]
+		return NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			record := streamValue(stream, maybe.(*Maybe).Value)
+			result := NewDict(map[string]Object{})
+			for _, field := range fields {
+				value := fieldOf(scope, record, field)
+				if isRaise(value) {
+					return value
+				}
+				result.Set(field.AsString(), value)
+			}
+			return YieldWith(result)
+		}, scope).WithUpstream(stream)
+	},
+	`project`,
+	`Yields dicts with only the given fields of each record.`,
+	P("stream"),
+	P("fields", V.Type(StringId)).AsSpread(),
+)
+
+var Rename = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		names := args[1].(*Dict)
+		return NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			record := recordToDict(scope, streamValue(stream, maybe.(*Maybe).Value))
+			if isRaise(record) {
+				return record
+			}
+
+			result := NewDict(map[string]Object{})
+			dict := record.(*Dict)
+			for _, key := range dict.Keys() {
+				value := dict.Elements[key]
+				if name, ok := names.Elements[key]; ok {
+					key = name.AsString()
+				}
+				result.Set(key, value)
+			}
+			return YieldWith(result)
+		}, scope).WithUpstream(stream)
+	},
+	`rename`,
+	`Yields dicts with the fields of each record renamed by the given dict, mapping old names to new ones.`,
+	P("stream"),
+	P("names", V.Type(DictId)),
+)
+
+var Aggregate = F(
+	func(scope *Scope, args ...Object) Object {
+		spec := args[1].(*Dict)
+
+		// Groups from groupBy are aggregated separately
+		if groups, ok := args[0].(*Dict); ok {
+			result := NewDict(map[string]Object{})
+			for _, key := range groups.Keys() {
+				group := groups.Elements[key]
+				list, ok := group.(*List)
+				if !ok {
+					return scope.Interrupt(Raise("expected a dict of lists, got '%s' for key '%s'", group.TypeId(), key))
+				}
+
+				ret := aggregateRecords(scope, list.Elements, spec)
+				if isRaise(ret) {
+					return ret
+				}
+				result.Set(key, ret)
+			}
+			return result
+		}
+
+		list := ListTypeObj.Convert(scope, args[0])
+		if isRaise(list) {
+			return list
+		}
+		return aggregateRecords(scope, list.(*List).Elements, spec)
+	},
+	`aggregate`,
+	`Aggregates the records into a dict, with one value for each entry of the spec. The spec values are functions receiving the list of records, or one of 'count', 'sum:field', 'avg:field', 'min:field', 'max:field', 'first:field' and 'last:field'. Dicts of lists, like the result of groupBy, are aggregated per group.`,
+	P("records"),
+	P("spec", V.Type(DictId)),
+)
+
+var Pivot = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		rowField, colField, valueField := args[1], args[2], args[3]
+		var agg Object = NewString("sum")
+		if len(args) > 4 {
+			agg = args[4]
+		}
+
+		// Collects the values of each cell, then aggregates them
+		type cell struct {
+			row, col string
+		}
+		cells := map[cell][]Object{}
+		order := []cell{}
+		keyOf := map[string]Object{} // the key objects of the rows and columns
+		ret := stream.Resolve(func(value Object) Object {
+			record := streamValue(stream, value)
+			keys := make([]string, 2)
+			for i, field := range []Object{rowField, colField} {
+				key := fieldOf(scope, record, field)
+				if isRaise(key) {
+					return key
+				}
+
+				hash := HashKey(scope, key)
+				if isRaise(hash) {
+					return hash
+				}
+				keys[i] = hash.AsString()
+				keyOf[keys[i]] = key
+			}
+
+			c := cell{keys[0], keys[1]}
+			if _, ok := cells[c]; !ok {
+				order = append(order, c)
+			}
+			cells[c] = append(cells[c], record)
+			return nil
+		})
+		if isRaise(ret) {
+			return ret
+		}
+
+		spec := NewDict(map[string]Object{})
+		switch agg := agg.(type) {
+		case *String:
+			op := agg.Value
+			if op != "count" {
+				op += ":" + valueField.AsString()
+			}
+			spec.Set("value", NewString(op))
+		default:
+			spec.Set("value", agg)
+		}
+
+		result := NewDict(map[string]Object{})
+		for _, c := range order {
+			row, ok := result.Elements[c.row].(*Dict)
+			if !ok {
+				row = NewDict(map[string]Object{})
+				result.SetKey(c.row, keyOf[c.row], row)
+			}
+
+			ret := aggregateRecords(scope, cells[c], spec)
+			if isRaise(ret) {
+				return ret
+			}
+			row.SetKey(c.col, keyOf[c.col], ret.(*Dict).Elements["value"])
+		}
+		return result
+	},
+	`pivot`,
+	`Returns a dict of dicts, indexed by the row and column fields, with the values of the value field aggregated by the given aggregation ('sum' by default, as in aggregate).`,
+	P("stream"),
+	P("rowField"),
+	P("colField"),
+	P("valueField"),
+	P("agg"),
+)
+
+func joinStreams(scope *Scope, args []Object, keepLeft, keepRight bool) Object {
+	s := StreamTypeObj.Convert(scope, args[0])
+	if isRaise(s) {
+		return s
+	}
+	left := s.(*Stream)
+
+	s = StreamTypeObj.Convert(scope, args[1])
+	if isRaise(s) {
+		return s
+	}
+	right := s.(*Stream)
+
+	leftKey := args[2]
+	rightKey := leftKey
+	if len(args) > 3 {
+		rightKey = args[3]
+	}
+
+	// The right records are indexed by key, keeping their order to yield the
+	// unmatched ones in outer joins
+	var index map[string][]int
+	var records []Object
+	var matched []bool
+	var pending []Object // tuples ready to be yielded
+	rightIdx := 0
+
+	return NewInternalStream(func(s *Scope) Object {
+		if index == nil {
+			index = map[string][]int{}
+			ret := right.Resolve(func(value Object) Object {
+				record := streamValue(right, value)
+				key := fieldOf(scope, record, rightKey)
+				if isRaise(key) {
+					return key
+				}
+
+				hash := HashKey(scope, key)
+				if isRaise(hash) {
+					return hash
+				}
+
+				index[hash.AsString()] = append(index[hash.AsString()], len(records))
+				records = append(records, record)
+				return nil
+			})
+			if isRaise(ret) {
+				return ret
+			}
+			matched = make([]bool, len(records))
+		}
+
+		for len(pending) == 0 {
+			if left.Finished {
+				if !keepRight {
+					return nil
+				}
+
+				for ; rightIdx < len(records); rightIdx++ {
+					if !matched[rightIdx] {
+						rightIdx++
+						return YieldWith(NewTuple(joinSide(nil, true), joinSide(records[rightIdx-1], keepLeft)))
+					}
+				}
+				return nil
+			}
+
+			maybe := Stream_Next.Call(s, left)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if left.Finished {
+				continue
+			}
+
+			record := streamValue(left, maybe.(*Maybe).Value)
+			key := fieldOf(scope, record, leftKey)
+			if isRaise(key) {
+				return key
+			}
+
+			hash := HashKey(scope, key)
+			if isRaise(hash) {
+				return hash
+			}
+
+			for _, i := range index[hash.AsString()] {
+				matched[i] = true
+				pending = append(pending, NewTuple(joinSide(record, keepRight), joinSide(records[i], keepLeft)))
+			}
+
+			if len(index[hash.AsString()]) == 0 && keepLeft {
+				pending = append(pending, NewTuple(joinSide(record, keepRight), joinSide(nil, true)))
+			}
+		}
+
+		ret := pending[0]
+		pending = pending[1:]
+		return YieldWith(ret)
+	}, scope).WithUpstream(left, right)
+}
+
+// Returns the record of a join, as a Maybe if that side may be missing. Nil
+// records are the missing ones, becoming a Maybe error.
+func joinSide(record Object, optional bool) Object {
+	switch {
+	case !optional:
+		return record
+	case record == nil:
+		return NewMaybe(NewErrorFromString("no matching record"))
+	}
+	return NewMaybe(record)
+}
+
+func aggregateRecords(scope *Scope, records []Object, spec *Dict) Object {
+	result := NewDict(map[string]Object{})
+	for _, name := range spec.Keys() {
+		op := spec.Elements[name]
+		var value Object
+		switch op := op.(type) {
+		case *String:
+			value = aggregateField(scope, records, op.Value)
+		default:
+			value = scope.Eval().Call(scope, op, []Object{NewList(records...)})
+		}
+
+		if isRaise(value) {
+			return value
+		}
+		result.Set(name, value)
+	}
+	return result
+}
+
+// Resolves the string aggregations, in the format `op` or `op:field`.
+func aggregateField(scope *Scope, records []Object, spec string) Object {
+	op, field, _ := strings.Cut(spec, ":")
+	if op == "count" {
+		return NewNumber(float64(len(records)))
+	}
+
+	if field == "" {
+		return scope.Interrupt(Raise("aggregation '%s' requires a field, like '%s:field'", op, op))
+	}
+
+	values := make([]Object, len(records))
+	for i, record := range records {
+		value := fieldOf(scope, record, NewString(field))
+		if isRaise(value) {
+			return value
+		}
+		values[i] = value
+	}
+
+	switch op {
+	case "sum", "avg":
+		sum := 0.
+		for _, value := range values {
+			number, ok := value.(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("expected number in field '%s', got '%s'", field, value.TypeId()))
+			}
+			sum += number.Value
+		}
+
+		if op == "sum" {
+			return NewNumber(sum)
+		}
+		if len(values) == 0 {
+			return Zero
+		}
+		return NewNumber(sum / float64(len(values)))
+
+	case "min", "max":
+		var best Object
+		for _, value := range values {
+			if best == nil {
+				best = value
+				continue
+			}
+
+			cmp := Compare(scope, value, best)
+			if isRaise(cmp) {
+				return cmp
+			}
+
+			v := cmp.(*Number).Value
+			if op == "min" && v < 0 || op == "max" && v > 0 {
+				best = value
+			}
+		}
+
+		if best == nil {
+			return False
+		}
+		return best
+
+	case "first", "last":
+		if len(values) == 0 {
+			return False
+		}
+		if op == "first" {
+			return values[0]
+		}
+		return values[len(values)-1]
+	}
+
+	return scope.Interrupt(Raise("unknown aggregation '%s'", op))
+}
+
+// Returns the field of a record, given by name or by a function.
+func fieldOf(scope *Scope, record Object, field Object) Object {
+	if field.TypeId() == FunctionId {
+		return scope.Eval().Call(scope, field, []Object{record})
+	}
+
+	name := field.AsString()
+	switch record := record.(type) {
+	case *Dict:
+		return Dict_Get.Call(scope, record, field)
+
+	case *Data:
+		value := record.GetProperty(name)
+		if value == nil {
+			return scope.Interrupt(Raise("field '%s' not found", name))
+		}
+		return value
+	}
+
+	return scope.Interrupt(Raise("expected a dict or data record, got '%s'", record.TypeId()))
+}
+
+// Converts a record into a dict with its fields.
+func recordToDict(scope *Scope, record Object) Object {
+	switch record := record.(type) {
+	case *Dict:
+		return record
+
+	case *Data:
+		result := NewDict(map[string]Object{})
+		for _, name := range record.Type().(*DataType).AttributeNames() {
+			result.Set(name, record.GetProperty(name))
+		}
+		return result
+	}
+
+	return scope.Interrupt(Raise("expected a dict or data record, got '%s'", record.TypeId()))
+}
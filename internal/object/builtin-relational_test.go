@@ -0,0 +1,64 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+var users = ` users := [{id=1, name='ann'}, {id=2, name='bob'}, {id=3, name='cid'}];`
+var orders = ` orders := [{user=1, total=10}, {user=1, total=5}, {user=3, total=7}, {user=9, total=1}];`
+
+func TestFunction_Join(t *testing.T) {
+	common.AssertCode(t, users+orders+` join(users, orders, 'id', 'user') | map (u, o): [u['name'], o['total']] | List`, `[['ann', 10], ['ann', 5], ['cid', 7]]`)
+	common.AssertCode(t, users+` join(users, users, 'id') | count`, `3`)
+	common.AssertCode(t, users+orders+` join(users, orders, u: u['id'] * 3, o: o['user']) | map (u, o): u['name'] | List`, `['ann', 'cid']`)
+	common.AssertCodeError(t, users+orders+` join(users, orders, 'id') | List`)
+}
+
+func TestFunction_LeftJoin(t *testing.T) {
+	common.AssertCode(t, users+orders+` leftJoin(users, orders, 'id', 'user') | filter (u, o): not o.Ok() | map (u, o): u['name'] | List`, `['bob']`)
+	common.AssertCode(t, users+orders+` leftJoin(users, orders, 'id', 'user') | count`, `4`)
+	common.AssertCode(t, users+orders+` leftJoin(users, orders, 'id', 'user') | map (u, o): [u['name'], o.Ok()] | List`, `[['ann', true], ['ann', true], ['bob', false], ['cid', true]]`)
+	common.AssertCode(t, ` leftJoin([{id=1}], [{id=2, ok=false}, {id=1, ok=false}], 'id') | map (l, r): r.Value()['ok'] | List`, `[false]`)
+}
+
+func TestFunction_OuterJoin(t *testing.T) {
+	common.AssertCode(t, users+orders+` outerJoin(users, orders, 'id', 'user') | count`, `5`)
+	common.AssertCode(t, users+orders+` outerJoin(users, orders, 'id', 'user') | filter (u, o): not u.Ok() | map (u, o): o.Value()['total'] | List`, `[1]`)
+	common.AssertCode(t, users+orders+` outerJoin(users, orders, 'id', 'user') | filter (u, o): not o.Ok() | map (u, o): [u.Value()['name'], sprint(o.Error())] | List`, `[['bob', 'no matching record']]`)
+}
+
+func TestFunction_OrderBy(t *testing.T) {
+	common.AssertCode(t, orders+` orders | orderBy '-total' | map x: x['total'] | List`, `[10, 7, 5, 1]`)
+	common.AssertCode(t, orders+` orders | orderBy 'user', '-total' | map x: x['total'] | List`, `[10, 5, 7, 1]`)
+	common.AssertCode(t, orders+` orders | orderBy x: -x['user'] | map x: x['total'] | List`, `[1, 7, 10, 5]`)
+	common.AssertCode(t, ` data P { age = 0 }; [P { age = 3 }, P { age = 1 }] | orderBy 'age' | map x: x.age | List`, `[1, 3]`)
+	common.AssertCodeError(t, orders+` orders | orderBy 'missing'`)
+}
+
+func TestFunction_Project(t *testing.T) {
+	common.AssertCode(t, users+` users | project 'name' | map x: x.Size() | List`, `[1, 1, 1]`)
+	common.AssertCode(t, ` data P { name = ''; age = 0 }; [P { name = 'x' }] | project 'name' | map x: x['name'] | List`, `['x']`)
+	common.AssertCodeError(t, users+` users | project 'age' | List`)
+}
+
+func TestFunction_Rename(t *testing.T) {
+	common.AssertCode(t, users+` users | rename {name='user'} | map x: x['user'] | List`, `['ann', 'bob', 'cid']`)
+	common.AssertCode(t, ` data P { age = 0 }; [P { age = 3 }] | rename {age='years'} | map x: x['years'] | List`, `[3]`)
+}
+
+func TestFunction_Aggregate(t *testing.T) {
+	common.AssertCode(t, orders+` a := orders | aggregate {n='count', total='sum:total'}; [a['n'], a['total']]`, `[4, 23]`)
+	common.AssertCode(t, orders+` a := orders | groupBy x: x['user'] | aggregate {avg='avg:total', max='max:total'}; [a[1]['avg'], a[1]['max'], a[9]['avg']]`, `[7.500000, 10, 1]`)
+	common.AssertCode(t, orders+` a := orders | aggregate {n=xs: xs.Size() * 2}; a['n']`, `8`)
+	common.AssertCode(t, orders+` a := orders | aggregate {f='first:total', l='last:total', m='min:total'}; [a['f'], a['l'], a['m']]`, `[10, 1, 1]`)
+	common.AssertCodeError(t, orders+` orders | aggregate {n='median:total'}`)
+	common.AssertCodeError(t, orders+` orders | aggregate {n='sum'}`)
+}
+
+func TestFunction_Pivot(t *testing.T) {
+	common.AssertCode(t, ` sales := [{y=1, q=1, v=10}, {y=1, q=1, v=5}, {y=1, q=2, v=3}, {y=2, q=1, v=4}]; p := sales | pivot 'y', 'q', 'v'; [p[1][1], p[1][2], p[2][1]]`, `[15, 3, 4]`)
+	common.AssertCode(t, ` sales := [{y=1, q=1, v=10}, {y=1, q=1, v=5}]; p := sales | pivot 'y', 'q', 'v', 'count'; p[1][1]`, `2`)
+	common.AssertCode(t, ` sales := [{y=1, q=1, v=10}, {y=1, q=1, v=5}]; p := sales | pivot 'y', 'q', 'v', 'max'; p[1][1]`, `10`)
+}
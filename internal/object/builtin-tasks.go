@@ -0,0 +1,76 @@
+package object
+
+import "reflect"
+
+var WaitAll = F(
+	func(scope *Scope, args ...Object) Object {
+		tasks, err := tasksOf(scope, args)
+		if err != nil {
+			return err
+		}
+
+		results := make([]Object, len(tasks))
+		for i, task := range tasks {
+			result, err := task.Wait(scope)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+		}
+		return NewList(results...)
+	},
+	`waitAll`,
+	`Waits for all tasks, returning a list with their results as Maybes, in the same order. Accepts the tasks as arguments or as a list.`,
+	P("tasks").AsSpread(),
+)
+
+var WaitAny = F(
+	func(scope *Scope, args ...Object) Object {
+		tasks, err := tasksOf(scope, args)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return scope.Interrupt(Raise("waitAny requires at least one task"))
+		}
+
+		cases := make([]reflect.SelectCase, len(tasks))
+		for i, task := range tasks {
+			cases[i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(task.done),
+			}
+		}
+
+		chosen, _, _, err := scope.waitPeers(cases)
+		if err != nil {
+			return err
+		}
+		return NewTuple(tasks[chosen].result, NewNumber(float64(chosen)))
+	},
+	`waitAny`,
+	`Waits for the first task to finish, returning a (result, index) tuple. Accepts the tasks as arguments or as a list.`,
+	P("tasks").AsSpread(),
+)
+
+// Returns the tasks of the arguments, which may also be a single list, tuple
+// or stream of tasks.
+func tasksOf(scope *Scope, args []Object) ([]*Task, Object) {
+	if len(args) == 1 && args[0].TypeId() != TaskId {
+		list := ListTypeObj.Convert(scope, args[0])
+		if isRaise(list) {
+			return nil, list
+		}
+		args = list.(*List).Elements
+	}
+
+	tasks := make([]*Task, len(args))
+	for i, arg := range args {
+		task, ok := arg.(*Task)
+		if !ok {
+			return nil, scope.Interrupt(Raise("expected a task, got '%s'", arg.TypeId()))
+		}
+		tasks[i] = task
+	}
+	return tasks, nil
+}
@@ -0,0 +1,21 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+var slow = ` slow := fn(x, ms) { sleep(ms); x };`
+
+func TestFunction_WaitAll(t *testing.T) {
+	common.AssertCode(t, slow+` waitAll(go slow(1, 30), go slow(2, 10)) | map x: x.Value() | List`, `[1, 2]`)
+	common.AssertCode(t, slow+` [go slow(1, 10), go raise 'boom'] | waitAll | map x: x.Ok() | List`, `[true, false]`)
+	common.AssertCode(t, ` waitAll([])`, `[]`)
+	common.AssertCodeError(t, ` waitAll(1, 2)`)
+}
+
+func TestFunction_WaitAny(t *testing.T) {
+	common.AssertCode(t, slow+` r, i := waitAny(go slow(1, 200), go slow(2, 1)); [r.Value(), i]`, `[2, 1]`)
+	common.AssertCodeError(t, ` waitAny([])`)
+}
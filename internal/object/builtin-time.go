@@ -0,0 +1,456 @@
+package object
+
+import (
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Time-aware functions use the clock of the scope, so they can be tested
+// without sleeping. Durations are given as Duration values or in milliseconds.
+// Since streams are pull-based, the time of an element is the moment it
+// arrives from upstream.
+// ----------------------------------------------------------------------------
+
+var Sleep = F(
+	func(scope *Scope, args ...Object) Object {
+		d, err := durationOf(scope, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := sleepFor(scope, d); err != nil {
+			return err
+		}
+		return False
+	},
+	`sleep`,
+	`Pauses the execution for the given duration.`,
+	P("duration"),
+)
+
+var After = F(
+	func(scope *Scope, args ...Object) Object {
+		d, err := durationOf(scope, args[0])
+		if err != nil {
+			return err
+		}
+
+		clock := scope.Clock()
+		ch := NewChannel(1)
+		ch.timer = &channelTimer{
+			clock:    clock,
+			deadline: clock.Now().Add(d),
+			value:    NewNumber(float64(d.Milliseconds())),
+		}
+		return ch
+	},
+	`after`,
+	`Returns a channel receiving the duration once it passes. In a select, it is only chosen if the other cases are not ready.`,
+	P("duration"),
+)
+
+var Interval = F(
+	func(scope *Scope, args ...Object) Object {
+		return tickStream(scope, args[0], func(tick int, elapsed time.Duration) Object {
+			return NewNumber(float64(tick))
+		})
+	},
+	`interval`,
+	`Yields 0, 1, 2, ... indefinitely, one number at the end of each period of the given duration.`,
+	P("duration"),
+)
+
+var Ticker = F(
+	func(scope *Scope, args ...Object) Object {
+		return tickStream(scope, args[0], func(tick int, elapsed time.Duration) Object {
+			return NewNumber(float64(elapsed.Milliseconds()))
+		})
+	},
+	`ticker`,
+	`Like interval, but yields the milliseconds elapsed since the first pull.`,
+	P("duration"),
+)
+
+var Throttle = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		// Spaces the pulls instead of the yields, so the work done upstream is
+		// rate limited too
+		clock := scope.Clock()
+		var last time.Time
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			if !last.IsZero() {
+				if wait := d - clock.Now().Sub(last); wait > 0 {
+					if err := sleepFor(scope, wait); err != nil {
+						return err
+					}
+				}
+			}
+
+			last = clock.Now()
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope))
+	},
+	`throttle`,
+	`Limits the rate of the stream to one element per duration, waiting before pulling from upstream when needed. No element is dropped.`,
+	P("stream"),
+	P("duration"),
+)
+
+var Debounce = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		clock := scope.Clock()
+		var pending Object
+		var arrival time.Time
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					if pending == nil {
+						return nil
+					}
+					ret := pending
+					pending = nil
+					return YieldWith(ret)
+				}
+
+				now := clock.Now()
+				ret := pending
+				quiet := now.Sub(arrival) >= d
+				pending, arrival = maybe.(*Maybe).Value, now
+				if ret != nil && quiet {
+					return YieldWith(ret)
+				}
+			}
+		}, scope))
+	},
+	`debounce`,
+	`Yields only the elements that are not followed by another one within the duration. The last element is always yielded.`,
+	P("stream"),
+	P("duration"),
+)
+
+var BufferTime = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		limit := 0
+		if len(args) > 2 {
+			limit = int(args[2].(*Number).Value)
+		}
+
+		periods := newPeriods(scope.Clock(), d)
+		var buffer []Object
+		var next Object // first element of the next buffer
+		return NewInternalStream(func(s *Scope) Object {
+			periods.start()
+			if next != nil {
+				buffer = append(buffer, next)
+				next = nil
+			}
+
+			for limit <= 0 || len(buffer) < limit {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					break
+				}
+
+				value := streamValue(stream, maybe.(*Maybe).Value)
+				if periods.advance() && len(buffer) > 0 {
+					next = value
+					break
+				}
+				buffer = append(buffer, value)
+			}
+
+			if len(buffer) == 0 {
+				return nil
+			}
+			ret := NewList(buffer...)
+			buffer = nil
+			return YieldWith(ret)
+		}, scope).WithUpstream(stream)
+	},
+	`bufferTime`,
+	`Groups the elements arriving in the same period of the given duration into lists. If a maximum size is given, buffers are also yielded when full. Periods without elements are skipped.`,
+	P("stream"),
+	P("duration"),
+	P("maxSize"),
+)
+
+var Sample = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		periods := newPeriods(scope.Clock(), d)
+		var latest Object
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			periods.start()
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					if latest == nil {
+						return nil
+					}
+					ret := latest
+					latest = nil
+					return YieldWith(ret)
+				}
+
+				ret := latest
+				latest = maybe.(*Maybe).Value
+				if periods.advance() && ret != nil {
+					return YieldWith(ret)
+				}
+			}
+		}, scope))
+	},
+	`sample`,
+	`Yields the latest element of each period of the given duration. Periods without elements are skipped.`,
+	P("stream"),
+	P("duration"),
+)
+
+var Delay = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			if err := sleepFor(scope, d); err != nil {
+				return err
+			}
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope))
+	},
+	`delay`,
+	`Waits for the given duration before yielding each element.`,
+	P("stream"),
+	P("duration"),
+)
+
+var Timeout = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+
+		// Pulls from upstream in its own goroutine, racing the clock. A pull
+		// that times out is left running in the background
+		clock := scope.Clock()
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			start := clock.Now()
+			pulled := make(chan Object, 1)
+			go func() {
+				ps := s.New()
+				ps.Acquire()
+				defer ps.Release()
+				pulled <- Stream_Next.Call(ps, stream)
+			}()
+
+			var maybe Object
+			s.Blocking(func() {
+				select {
+				case maybe = <-pulled:
+				case <-clock.Until(start.Add(d)):
+				}
+			})
+
+			if maybe == nil {
+				return scope.Interrupt(Raise("stream timed out after %dms", d.Milliseconds()))
+			}
+			if isRaise(maybe) {
+				return maybe
+			}
+			if elapsed := clock.Now().Sub(start); elapsed > d {
+				return scope.Interrupt(Raise("stream timed out after %dms, waiting %dms", d.Milliseconds(), elapsed.Milliseconds()))
+			}
+			if stream.Finished {
+				return nil
+			}
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope))
+	},
+	`timeout`,
+	`Raises if the stream takes longer than the duration to yield an element or to finish, without waiting for upstream to return.`,
+	P("stream"),
+	P("duration"),
+)
+
+// Yields the result of fn at the end of each period of d, starting at the
+// first pull. Slow consumers skip the periods they missed.
+func tickStream(scope *Scope, obj Object, fn func(tick int, elapsed time.Duration) Object) Object {
+	d, err := durationOf(scope, obj)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return scope.Interrupt(Raise("interval duration must be positive, got %dms", d.Milliseconds()))
+	}
+
+	clock := scope.Clock()
+	var start, next time.Time
+	tick := 0
+	return NewInternalStream(func(s *Scope) Object {
+		now := clock.Now()
+		if start.IsZero() {
+			start, next = now, now.Add(d)
+		}
+
+		if wait := next.Sub(now); wait > 0 {
+			if err := sleepFor(scope, wait); err != nil {
+				return err
+			}
+		}
+
+		now = clock.Now()
+		ret := fn(tick, now.Sub(start))
+		tick++
+		for !next.After(now) {
+			next = next.Add(d)
+		}
+		return YieldWith(ret)
+	}, scope)
+}
+
+// Splits the time in consecutive periods of a fixed duration, starting when
+// the first element is requested.
+type periods struct {
+	clock   Clock
+	d       time.Duration
+	started bool
+	end     time.Time
+}
+
+func newPeriods(clock Clock, d time.Duration) *periods {
+	return &periods{clock: clock, d: d}
+}
+
+func (p *periods) start() {
+	if !p.started {
+		p.started = true
+		p.end = p.clock.Now().Add(p.d)
+	}
+}
+
+// Moves to the period of the current time, returning true if it changed.
+func (p *periods) advance() bool {
+	now := p.clock.Now()
+	if now.Before(p.end) || p.d <= 0 {
+		return false
+	}
+
+	for !now.Before(p.end) {
+		p.end = p.end.Add(p.d)
+	}
+	return true
+}
+
+// Converts a Duration or a number of milliseconds into a duration.
+func durationOf(scope *Scope, obj Object) (time.Duration, Object) {
+	switch obj := obj.(type) {
+	case *Duration:
+		return obj.Value, nil
+	case *Number:
+		return time.Duration(obj.Value * float64(time.Millisecond)), nil
+	}
+
+	return 0, scope.Interrupt(Raise("expected a duration or a number of milliseconds, got '%s'", obj.TypeId()))
+}
+
+// Sleeps with the clock of the scope, releasing the interpreter lock so other
+// goroutines can run meanwhile. Raises if the scope is cancelled first.
+func sleepFor(scope *Scope, d time.Duration) Object {
+	clock := scope.Clock()
+	scope.Blocking(func() {
+		slept := make(chan struct{})
+		go func() {
+			clock.Sleep(d)
+			close(slept)
+		}()
+
+		select {
+		case <-slept:
+		case <-scope.Done():
+		}
+	})
+	return scope.Cancelled()
+}
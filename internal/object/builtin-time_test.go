@@ -0,0 +1,77 @@
+package object_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+// Elements arrive after the given number of milliseconds from the previous one
+var arrivals = ` arrivals := fn(xs) { xs | each x: sleep(x) };`
+
+func assertElapsed(t *testing.T, input string, output string, elapsed time.Duration) {
+	clock, obj, err := common.RunWithClock(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if obj.AsString() != output {
+		t.Errorf("expected %s, got %s", output, obj.AsString())
+	}
+	if got := clock.Now().Sub(time.Unix(0, 0)); got != elapsed {
+		t.Errorf("expected %s elapsed, got %s", elapsed, got)
+	}
+}
+
+func TestFunction_Sleep(t *testing.T) {
+	assertElapsed(t, ` sleep(150); sleep(50)`, `false`, 200*time.Millisecond)
+	assertElapsed(t, ` sleep(Duration('1m'))`, `false`, time.Minute)
+	common.AssertTimedCode(t, ` ticker(Duration.Second) | take 2 | List`, `[1000, 2000]`)
+	common.AssertCodeError(t, ` sleep('a')`)
+}
+
+func TestFunction_Interval(t *testing.T) {
+	assertElapsed(t, ` interval(100) | take 3 | List`, `[0, 1, 2]`, 300*time.Millisecond)
+	common.AssertTimedCode(t, ` ticker(100) | take 3 | List`, `[100, 200, 300]`)
+	common.AssertTimedCode(t, ` ticker(100) | each x: sleep(250) | take 2 | List`, `[100, 350]`)
+	common.AssertCodeError(t, ` interval(0)`)
+}
+
+func TestFunction_Throttle(t *testing.T) {
+	assertElapsed(t, ` [1, 2, 3] | throttle 100 | List`, `[1, 2, 3]`, 300*time.Millisecond)
+	assertElapsed(t, arrivals+` arrivals([150, 150]) | throttle 100 | List`, `[150, 150]`, 300*time.Millisecond)
+}
+
+func TestFunction_Debounce(t *testing.T) {
+	common.AssertTimedCode(t, arrivals+` arrivals([10, 10, 200, 10, 300, 10]) | debounce 100 | List`, `[10, 10, 10]`)
+	common.AssertTimedCode(t, arrivals+` arrivals([0, 20, 150, 30]) | debounce 100 | List`, `[20, 30]`)
+	common.AssertTimedCode(t, ` [] | debounce 100 | List`, `[]`)
+}
+
+func TestFunction_BufferTime(t *testing.T) {
+	common.AssertTimedCode(t, arrivals+` arrivals([10, 20, 30, 50, 10, 200]) | bufferTime 100 | List`, `[[10, 20, 30], [50, 10], [200]]`)
+	common.AssertTimedCode(t, arrivals+` arrivals([1, 1, 1, 1, 1]) | bufferTime 100, 2 | List`, `[[1, 1], [1, 1], [1]]`)
+}
+
+func TestFunction_Sample(t *testing.T) {
+	common.AssertTimedCode(t, arrivals+` arrivals([10, 20, 30, 50, 10, 200]) | sample 100 | List`, `[30, 10, 200]`)
+}
+
+func TestFunction_Delay(t *testing.T) {
+	assertElapsed(t, ` [1, 2] | delay 50 | List`, `[1, 2]`, 100*time.Millisecond)
+}
+
+func TestFunction_Timeout(t *testing.T) {
+	common.AssertTimedCode(t, arrivals+` arrivals([10, 20]) | timeout 50 | List`, `[10, 20]`)
+	_, _, err := common.RunWithClock(arrivals + ` arrivals([10, 80]) | timeout 50 | List`)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	// Upstream is not waited for
+	common.AssertCodeError(t, ` Channel().Stream() | timeout 20 | List`)
+	common.AssertCodeError(t, ` [1] | each x: sleep(60000) | timeout 20 | List`)
+	common.AssertCode(t, ` [1, 2] | each x: sleep(1) | timeout 1000 | List`, `[1, 2]`)
+}
@@ -0,0 +1,397 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var ChannelId = TypeIdentifier("Channel")
+var ChannelTypeObj = NewChannelType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type ChannelType struct {
+	*BaseObjectType
+}
+
+func NewChannelType() *ChannelType {
+	t := &ChannelType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			ChannelId,
+		),
+	}
+
+	t.AddMethod(Channel_Send)
+	t.AddMethod(Channel_Receive)
+	t.AddMethod(Channel_Close)
+	t.AddMethod(Channel_Closed)
+	t.AddMethod(Channel_Size)
+	t.AddMethod(Channel_Cap)
+
+	return t
+}
+
+// Instantiate creates an unbuffered channel, as in `Channel()`.
+func (o *ChannelType) Instantiate(scope *Scope) Object {
+	return NewChannel(0)
+}
+
+// Convert creates a buffered channel, as in `Channel(10)`.
+func (o *ChannelType) Convert(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *Channel:
+		return obj
+
+	case *Number:
+		if obj.Value < 0 {
+			return scope.Interrupt(Raise("channel size cannot be negative, got %s", obj.AsString()))
+		}
+		return NewChannel(int(obj.Value))
+	}
+
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Channel'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+// Channel wraps a Go channel. The Go channel itself is never closed, since a
+// blocked send would panic; closing is signaled by the done channel instead.
+type Channel struct {
+	*BaseObject
+	ch     chan Object
+	done   chan struct{}
+	mutex  sync.Mutex
+	closed bool
+	timer  *channelTimer // set by `after`, only chosen by select if nothing else is ready
+}
+
+// channelTimer fires once, when a receive chooses it. Only then the clock is
+// moved to the deadline, so a fake clock does not move for the timers that
+// lose a select.
+type channelTimer struct {
+	clock    Clock
+	deadline time.Time
+	value    Object
+	fired    bool
+}
+
+func NewChannel(size int) *Channel {
+	return &Channel{
+		BaseObject: NewBaseObject(ChannelTypeObj),
+		ch:         make(chan Object, size),
+		done:       make(chan struct{}),
+	}
+}
+
+func (o *Channel) IsClosed() bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.closed
+}
+
+// Send blocks until the value is received or buffered, releasing the
+// interpreter lock meanwhile. Sending to a closed channel raises, as does
+// sending when no other goroutine can ever receive.
+func (o *Channel) Send(scope *Scope, value Object) Object {
+	if o.IsClosed() {
+		return scope.Interrupt(Raise("send on closed channel"))
+	}
+
+	chosen, _, _, err := scope.waitPeers([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: reflect.ValueOf(o.ch), Send: reflect.ValueOf(&value).Elem()},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.done)},
+	})
+	if err != nil {
+		return err
+	}
+	if chosen == 1 {
+		return scope.Interrupt(Raise("send on closed channel"))
+	}
+	return nil
+}
+
+// Receive blocks until a value is available, releasing the interpreter lock
+// meanwhile. Returns a Maybe, holding an error if the channel is closed.
+// Raises if no other goroutine can ever send.
+func (o *Channel) Receive(scope *Scope) Object {
+	if maybe, ok := o.fire(scope); ok {
+		return maybe
+	}
+
+	chosen, value, ok, err := scope.waitPeers([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.ch)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.done)},
+	})
+	if err != nil {
+		return err
+	}
+	if chosen == 1 {
+		received, ok := o.drain()
+		return receivedMaybe(received, ok)
+	}
+	return receivedMaybe(value.Interface().(Object), ok)
+}
+
+// Returns true if the channel is a timer that did not fire yet.
+func (o *Channel) pending() bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.timer != nil && !o.timer.fired && !o.closed
+}
+
+// Fires the timer of the channel, waiting until its deadline, and returns the
+// received Maybe. Returns false if the channel is not a pending timer.
+func (o *Channel) fire(scope *Scope) (Object, bool) {
+	o.mutex.Lock()
+	if o.timer == nil || o.timer.fired || o.closed {
+		o.mutex.Unlock()
+		return nil, false
+	}
+	o.timer.fired = true
+	o.mutex.Unlock()
+
+	if err := sleepFor(scope, o.timer.deadline.Sub(o.timer.clock.Now())); err != nil {
+		return err, true
+	}
+	return NewMaybe(o.timer.value), true
+}
+
+// Returns a buffered value of a closed channel, if any.
+func (o *Channel) drain() (Object, bool) {
+	select {
+	case value := <-o.ch:
+		return value, true
+	default:
+		return nil, false
+	}
+}
+
+func (o *Channel) Close(scope *Scope) Object {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.closed {
+		return scope.Interrupt(Raise("channel already closed"))
+	}
+
+	o.closed = true
+	close(o.done)
+	return nil
+}
+
+// Stream yields the received values until the channel is closed.
+func (o *Channel) Stream(scope *Scope) *Stream {
+	return NewInternalStream(func(s *Scope) Object {
+		received := o.Receive(s)
+		if isRaise(received) {
+			return received
+		}
+		if maybe := received.(*Maybe); maybe.Ok {
+			return YieldWith(maybe.Value)
+		}
+		return nil
+	}, scope)
+}
+
+func (o *Channel) AsBool() bool {
+	return true
+}
+
+func (o *Channel) AsString() string {
+	return fmt.Sprintf("<channel %d/%d>", len(o.ch), cap(o.ch))
+}
+
+func (o *Channel) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Channel) AsInterface() any {
+	return o.AsString()
+}
+
+// ChannelOp is a channel operation waited by a select. Value is the value to
+// send, or nil to receive.
+type ChannelOp struct {
+	Channel *Channel
+	Value   Object
+}
+
+// SelectChannels runs the first operation to become ready, returning its
+// index and, for receives, the received value as a Maybe. If wait is false,
+// returns -1 when no operation is ready. Timers are only chosen if the other
+// operations are not ready. A fake clock only moves when told, so instead of
+// waiting, the first timer fires right away.
+func SelectChannels(scope *Scope, ops []ChannelOp, wait bool) (int, Object) {
+	// Each operation has two cases: the operation itself and the close of
+	// its channel
+	cases := make([]reflect.SelectCase, 2*len(ops))
+	ready := make([]reflect.SelectCase, 2*len(ops), 2*len(ops)+1)
+	for i, op := range ops {
+		if op.Value == nil {
+			cases[2*i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(op.Channel.ch)}
+		} else {
+			cases[2*i] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(op.Channel.ch), Send: reflect.ValueOf(&op.Value).Elem()}
+		}
+		cases[2*i+1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(op.Channel.done)}
+
+		// Tries the ready operations first, leaving the timers out. Cases
+		// without channel are ignored
+		ready[2*i], ready[2*i+1] = cases[2*i], cases[2*i+1]
+		if op.Channel.timer != nil {
+			ready[2*i] = reflect.SelectCase{Dir: reflect.SelectRecv}
+			ready[2*i+1] = reflect.SelectCase{Dir: reflect.SelectRecv}
+		}
+	}
+	ready = append(ready, reflect.SelectCase{Dir: reflect.SelectDefault})
+
+	chosen, value, ok := reflect.Select(ready)
+	if chosen == len(cases) {
+		if !wait {
+			return -1, nil
+		}
+
+		// Timers end the wait by themselves, the other operations need
+		// another goroutine
+		first := -1
+		for i, op := range ops {
+			if op.Value != nil || !op.Channel.pending() {
+				continue
+			}
+			if first < 0 || op.Channel.timer.deadline.Before(ops[first].Channel.timer.deadline) {
+				first = i
+			}
+
+			// Waits for the deadline instead of the channel
+			until := op.Channel.timer.clock.Until(op.Channel.timer.deadline)
+			cases[2*i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(until)}
+		}
+
+		if first >= 0 {
+			if _, fake := ops[first].Channel.timer.clock.(*FakeClock); fake {
+				received, _ := ops[first].Channel.fire(scope)
+				return first, received
+			}
+		}
+
+		if first < 0 {
+			var err Object
+			chosen, value, ok, err = scope.waitPeers(cases)
+			if err != nil {
+				return -1, err
+			}
+		} else {
+			cancel := reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(scope.Done())}
+			scope.Blocking(func() {
+				chosen, value, ok = reflect.Select(append(cases, cancel))
+			})
+			if chosen == len(cases) {
+				return -1, scope.Cancelled()
+			}
+		}
+	}
+
+	op := ops[chosen/2]
+	closed := chosen%2 == 1
+	if op.Value == nil && !closed && op.Channel.pending() {
+		if received, ok := op.Channel.fire(scope); ok {
+			return chosen / 2, received
+		}
+	}
+
+	switch {
+	case op.Value != nil && closed:
+		return -1, scope.Interrupt(Raise("send on closed channel"))
+
+	case op.Value != nil:
+		return chosen / 2, nil
+
+	case closed:
+		received, ok := op.Channel.drain()
+		return chosen / 2, receivedMaybe(received, ok)
+	}
+
+	return chosen / 2, receivedMaybe(value.Interface().(Object), ok)
+}
+
+func receivedMaybe(value Object, ok bool) *Maybe {
+	if !ok {
+		return NewMaybe(NewErrorFromString("channel closed"))
+	}
+	return NewMaybe(value)
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Channel_Send = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		if err := this.Send(scope, args[1]); err != nil {
+			return err
+		}
+		return this
+	},
+	`Send`,
+	`Sends the value to the channel, waiting until it is received or buffered. Raises if the channel is closed.`,
+	P("this", V.Type(ChannelId)),
+	P("value"),
+)
+
+var Channel_Receive = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		return this.Receive(scope)
+	},
+	`Receive`,
+	`Waits for a value of the channel, returning it as a Maybe. When the channel is closed and empty, the Maybe holds an error.`,
+	P("this", V.Type(ChannelId)),
+)
+
+var Channel_Close = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		if err := this.Close(scope); err != nil {
+			return err
+		}
+		return this
+	},
+	`Close`,
+	`Closes the channel. The buffered values can still be received, but no value can be sent.`,
+	P("this", V.Type(ChannelId)),
+)
+
+var Channel_Closed = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		return NewBoolean(this.IsClosed())
+	},
+	`Closed`,
+	`Returns true if the channel is closed.`,
+	P("this", V.Type(ChannelId)),
+)
+
+var Channel_Size = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		return NewNumber(float64(len(this.ch)))
+	},
+	`Size`,
+	`Returns the number of values in the buffer of the channel.`,
+	P("this", V.Type(ChannelId)),
+)
+
+var Channel_Cap = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Channel)
+		return NewNumber(float64(cap(this.ch)))
+	},
+	`Cap`,
+	`Returns the size of the buffer of the channel, 0 if unbuffered.`,
+	P("this", V.Type(ChannelId)),
+)
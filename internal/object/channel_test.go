@@ -0,0 +1,52 @@
+package object_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestChannel(t *testing.T) {
+	common.AssertCode(t, ` ch := Channel(); ch.Cap()`, `0`)
+	common.AssertCode(t, ` ch := Channel(3); ch.Send(1); ch.Send(2); [ch.Size(), ch.Cap()]`, `[2, 3]`)
+	common.AssertCodeError(t, ` Channel(-1)`)
+	common.AssertCodeError(t, ` Channel('a')`)
+}
+
+func TestChannel_SendReceive(t *testing.T) {
+	common.AssertCode(t, ` ch := Channel(1); ch.Send('a'); ch.Receive().Value()`, `a`)
+	common.AssertCode(t, ` ch := Channel(); go ch.Send(3); ch.Receive().Value()`, `3`)
+	common.AssertCode(t, ` ch := Channel(2); ch.Send(1); ch.Close(); [ch.Receive().Ok(), ch.Receive().Ok()]`, `[true, false]`)
+	common.AssertCodeError(t, ` ch := Channel(1); ch.Close(); ch.Send(1)`)
+}
+
+func TestChannel_Close(t *testing.T) {
+	common.AssertCode(t, ` ch := Channel(); a := ch.Closed(); ch.Close(); [a, ch.Closed()]`, `[false, true]`)
+	common.AssertCodeError(t, ` ch := Channel(); ch.Close(); ch.Close()`)
+	common.AssertCode(t, ` ch := Channel(); t := go ch.Send(1); sleep(10); ch.Close(); t.Wait().Ok()`, `false`)
+}
+
+func TestChannel_Deadlock(t *testing.T) {
+	common.AssertCode(t, ` (Channel().Receive())?.Error()`, `deadlock, all goroutines are waiting on each other`)
+	common.AssertCodeError(t, ` Channel().Send(1)`)
+	common.AssertCodeError(t, ` c := Channel(); t := go c.Receive(); t.Wait()`)
+	common.AssertCodeError(t, ` c := Channel(); select { c.Receive(): 1 }`)
+	common.AssertCodeError(t, ` c := Channel(); [1, 2] | pmap (x: c.Receive()) | List`)
+	common.AssertCode(t, ` c := Channel(); go fn() { sleep(150); c.Send(5) }(); c.Receive().Value()`, `5`)
+	common.AssertCode(t, ` c := Channel(); select { c.Receive(): 1; after(150): 2 }`, `2`)
+}
+
+func TestChannel_Stream(t *testing.T) {
+	common.AssertCode(t, ` ch := Channel(); go fn() { for i in range(3) { ch.Send(i) }; ch.Close() }(); ch | map x: x * 2 | List`, `[0, 2, 4]`)
+	common.AssertCode(t, ` ch := Channel(5); ch.Send(1); ch.Send(2); ch.Close(); s := 0; for x in ch { s += x }; s`, `3`)
+}
+
+func TestFunction_After(t *testing.T) {
+	common.AssertCode(t, ` after(10).Receive().Value()`, `10`)
+	assertElapsed(t, ` after(30).Receive().Value()`, `30`, 30*time.Millisecond)
+	assertElapsed(t, ` select { after(100): 'a'; after(50): 'b' }`, `b`, 50*time.Millisecond)
+	// The clock only moves for the timer chosen
+	assertElapsed(t, ` ch := Channel(1); ch.Send(1); select { after(100): 'timeout'; ch.Receive(): 'received' }`, `received`, 0)
+	assertElapsed(t, ` t := after(100); sleep(10); select { t: 'timeout'; _: 'default' }`, `default`, 10*time.Millisecond)
+}
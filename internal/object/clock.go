@@ -0,0 +1,110 @@
+package object
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by the time-aware functions. It can be
+// replaced in the scope to control the time, for instance, in tests.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+
+	// Until returns a channel that is closed once the clock passes the
+	// instant.
+	Until(t time.Time) <-chan struct{}
+}
+
+// RealClock uses the system time.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (RealClock) Until(t time.Time) <-chan struct{} {
+	ch := make(chan struct{})
+	time.AfterFunc(time.Until(t), func() {
+		close(ch)
+	})
+	return ch
+}
+
+// FakeClock only moves when it is told to. Sleeping advances the clock
+// immediately, so code depending on time runs deterministically. The channels
+// of Until are closed when the clock is moved past their instants.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan struct{}
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+func (c *FakeClock) Until(t time.Time) <-chan struct{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ch := make(chan struct{})
+	if c.now.After(t) {
+		close(ch)
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: t, ch: ch})
+	return ch
+}
+
+// Set moves the clock to the instant, freezing it there.
+func (c *FakeClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now
+	c.wake()
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+	c.wake()
+}
+
+// Closes the channels of the instants passed. Must hold the mutex.
+func (c *FakeClock) wake() {
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !c.now.After(w.at) {
+			waiting = append(waiting, w)
+		} else {
+			close(w.ch)
+		}
+	}
+	c.waiters = waiting
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/renatopp/pipelang/internal/ast"
 )
@@ -15,9 +16,11 @@ var DataId = TypeIdentifier("Data")
 // The data type should be created by user
 type DataType struct {
 	*BaseObjectType
-	Name       string
-	Attributes map[string]ast.Node
-	Methods    map[string]*Function
+	Name           string
+	Attributes     map[string]ast.Node
+	AttributeTypes map[string]ast.Node
+	Methods        map[string]*Function
+	Extensions     []*DataType
 }
 
 func NewDataType(name string, attributes map[string]ast.Node, methods map[string]*Function) *DataType {
@@ -46,6 +49,30 @@ func (o *DataType) AsString() string {
 	return fmt.Sprintf("<Data:%s>", o.Name)
 }
 
+// Is returns true if the data type is the given type or extends it.
+func (o *DataType) Is(other *DataType) bool {
+	if o.Id() == other.Id() {
+		return true
+	}
+
+	for _, ext := range o.Extensions {
+		if ext.Is(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeNames returns the attribute names of the data type, sorted.
+func (o *DataType) AttributeNames() []string {
+	names := make([]string, 0, len(o.Attributes))
+	for name := range o.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (o *DataType) Instantiate(scope *Scope) Object {
 	return &Data{
 		BaseObject: NewBaseObject(o),
@@ -78,6 +105,10 @@ func (o *Data) AsString() string {
 	}
 }
 
+func (o *Data) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *Data) AsRepr() string {
 	return o.AsString()
 }
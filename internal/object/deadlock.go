@@ -0,0 +1,81 @@
+package object
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// How long all goroutines must keep waiting on each other before it is taken
+// as a deadlock. A goroutine just woken up takes a moment to stop counting as
+// waiting, so the counts alone are not enough.
+const deadlockGrace = 100 * time.Millisecond
+
+// peerWaits counts the goroutines evaluating the code of a runtime, and the
+// ones among them waiting for another, like receiving from a channel. When all
+// of them wait, none can wake the others up.
+type peerWaits struct {
+	mutex   sync.Mutex
+	live    int
+	waiting int
+	changes int           // bumped on every change of the counts
+	stuck   chan struct{} // closed when a deadlock is found
+}
+
+func newPeerWaits() *peerWaits {
+	return &peerWaits{stuck: make(chan struct{})}
+}
+
+// Updates the counts, returning the channel closed if a deadlock is found.
+// The deadlock is confirmed only if the counts do not change for a while.
+func (p *peerWaits) add(live, waiting int) <-chan struct{} {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.live += live
+	p.waiting += waiting
+	p.changes++
+
+	stuck := p.stuck
+	if p.live == 0 || p.waiting < p.live {
+		return stuck
+	}
+
+	changes := p.changes
+	time.AfterFunc(deadlockGrace, func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		if p.changes == changes {
+			close(p.stuck)
+			p.stuck = make(chan struct{})
+		}
+	})
+	return stuck
+}
+
+// Waits for the first ready case, like reflect.Select, releasing the
+// interpreter lock meanwhile. Used for the waits that only other goroutines of
+// the runtime can end. Raises if the scope is cancelled, or if all goroutines
+// end up waiting on each other.
+func (s *Scope) waitPeers(cases []reflect.SelectCase) (int, reflect.Value, bool, Object) {
+	cases = append(cases[:len(cases):len(cases)],
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.Done())},
+		reflect.SelectCase{Dir: reflect.SelectRecv},
+	)
+
+	var chosen int
+	var value reflect.Value
+	var ok bool
+	s.Blocking(func() {
+		cases[len(cases)-1].Chan = reflect.ValueOf(s.peers.add(0, 1))
+		chosen, value, ok = reflect.Select(cases)
+		s.peers.add(0, -1)
+	})
+
+	switch chosen {
+	case len(cases) - 2:
+		return -1, value, false, s.Cancelled()
+	case len(cases) - 1:
+		return -1, value, false, s.Interrupt(Raise("deadlock, all goroutines are waiting on each other"))
+	}
+	return chosen, value, ok, nil
+}
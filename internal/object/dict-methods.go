@@ -28,8 +28,11 @@ func init() {
 var Dict_Set = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
-		index := args[1]
-		this.Elements[index.AsString()] = args[2]
+		key := HashKey(scope, args[1])
+		if isRaise(key) {
+			return key
+		}
+		this.SetKey(key.AsString(), args[1], args[2])
 		return this
 	},
 	`Set`,
@@ -43,7 +46,11 @@ var Dict_Get = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		index := args[1]
-		res, ok := this.Elements[index.AsString()]
+		key := HashKey(scope, index)
+		if isRaise(key) {
+			return key
+		}
+		res, ok := this.Elements[key.AsString()]
 		if !ok {
 			return scope.Interrupt(Raise("key not found: %s", index.AsString()))
 		}
@@ -60,7 +67,11 @@ var Dict_GetOr = F(
 		this := args[0].(*Dict)
 		index := args[1]
 		def := args[2]
-		res, ok := this.Elements[index.AsString()]
+		key := HashKey(scope, index)
+		if isRaise(key) {
+			return key
+		}
+		res, ok := this.Elements[key.AsString()]
 		if !ok {
 			return def
 		}
@@ -77,7 +88,11 @@ var Dict_Has = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		index := args[1]
-		_, ok := this.Elements[index.AsString()]
+		key := HashKey(scope, index)
+		if isRaise(key) {
+			return key
+		}
+		_, ok := this.Elements[key.AsString()]
 		return NewBoolean(ok)
 	},
 	`Has`,
@@ -90,11 +105,15 @@ var Dict_Remove = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		index := args[1]
-		res, ok := this.Elements[index.AsString()]
+		key := HashKey(scope, index)
+		if isRaise(key) {
+			return key
+		}
+		res, ok := this.Elements[key.AsString()]
 		if !ok {
 			return scope.Interrupt(Raise("key not found: %s", index.AsString()))
 		}
-		delete(this.Elements, index.AsString())
+		this.Delete(key.AsString())
 		return res
 	},
 	`Remove`,
@@ -125,7 +144,7 @@ var Dict_ContainsFn = F(
 		this := args[0].(*Dict)
 		f := args[1]
 		for k, v := range this.Elements {
-			if scope.eval.Call(scope, f, []Object{v, NewString(k)}).AsBool() {
+			if scope.eval.Call(scope, f, []Object{v, this.KeyOf(k)}).AsBool() {
 				return True
 			}
 		}
@@ -151,6 +170,8 @@ var Dict_Clear = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		this.Elements = make(map[string]Object)
+		this.order = nil
+		this.keys = nil
 		return this
 	},
 	`Clear`,
@@ -161,11 +182,11 @@ var Dict_Clear = F(
 var Dict_Copy = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
-		elements := make(map[string]Object)
-		for k, v := range this.Elements {
-			elements[k] = v
+		result := NewDict(map[string]Object{})
+		for _, k := range this.Keys() {
+			result.SetKey(k, this.KeyOf(k), this.Elements[k])
 		}
-		return NewDict(elements)
+		return result
 	},
 	`Copy`,
 	`Returns a copy of the dictionary.`,
@@ -176,13 +197,13 @@ var Dict_Concat = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		others := args[1:]
-		elements := this.Elements
 		for _, other := range others {
-			for k, v := range other.(*Dict).Elements {
-				elements[k] = v
+			other := other.(*Dict)
+			for _, k := range other.Keys() {
+				this.SetKey(k, other.KeyOf(k), other.Elements[k])
 			}
 		}
-		return NewDict(elements)
+		return this
 	},
 	`Concat`,
 	`Returns a new dictionary with the elements of both dictionaries.`,
@@ -194,10 +215,11 @@ var Dict_Find = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		elements := args[1:]
-		for k, v := range this.Elements {
+		for _, k := range this.Keys() {
+			v := this.Elements[k]
 			for _, element := range elements {
 				if scope.eval.Operator(scope, "==", v, element).AsBool() {
-					return NewString(k)
+					return this.KeyOf(k)
 				}
 			}
 		}
@@ -213,9 +235,9 @@ var Dict_FindFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		f := args[1]
-		for k, v := range this.Elements {
-			ko := NewString(k)
-			if scope.eval.Call(scope, f, []Object{v, ko}).AsBool() {
+		for _, k := range this.Keys() {
+			ko := this.KeyOf(k)
+			if scope.eval.Call(scope, f, []Object{this.Elements[k], ko}).AsBool() {
 				return ko
 			}
 		}
@@ -232,10 +254,11 @@ var Dict_FindAll = F(
 		this := args[0].(*Dict)
 		elements := args[1:]
 		var keys []Object
-		for k, v := range this.Elements {
+		for _, k := range this.Keys() {
+			v := this.Elements[k]
 			for _, element := range elements {
 				if scope.eval.Operator(scope, "==", v, element).AsBool() {
-					keys = append(keys, NewString(k))
+					keys = append(keys, this.KeyOf(k))
 				}
 			}
 		}
@@ -252,9 +275,9 @@ var Dict_FindAllFn = F(
 		this := args[0].(*Dict)
 		f := args[1]
 		var keys []Object
-		for k, v := range this.Elements {
-			ko := NewString(k)
-			if scope.eval.Call(scope, f, []Object{v, ko}).AsBool() {
+		for _, k := range this.Keys() {
+			ko := this.KeyOf(k)
+			if scope.eval.Call(scope, f, []Object{this.Elements[k], ko}).AsBool() {
 				keys = append(keys, ko)
 			}
 		}
@@ -301,7 +324,7 @@ var Dict_CountFn = F(
 		f := args[1]
 		var count int
 		for k, v := range this.Elements {
-			if scope.eval.Call(scope, f, []Object{v, NewString(k)}).AsBool() {
+			if scope.eval.Call(scope, f, []Object{v, this.KeyOf(k)}).AsBool() {
 				count++
 			}
 		}
@@ -317,13 +340,13 @@ var Dict_Keys = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		var keys []Object
-		for k := range this.Elements {
-			keys = append(keys, NewString(k))
+		for _, k := range this.Keys() {
+			keys = append(keys, this.KeyOf(k))
 		}
 		return NewList(keys...)
 	},
 	`Keys`,
-	`Returns a list of all keys in the dictionary.`,
+	`Returns a list of all keys in the dictionary, in insertion order.`,
 	P("this", V.Type(DictId)),
 )
 
@@ -331,8 +354,8 @@ var Dict_Values = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		var values []Object
-		for _, v := range this.Elements {
-			values = append(values, v)
+		for _, k := range this.Keys() {
+			values = append(values, this.Elements[k])
 		}
 		return NewList(values...)
 	},
@@ -345,8 +368,8 @@ var Dict_Items = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
 		var items []Object
-		for k, v := range this.Elements {
-			items = append(items, NewList(NewString(k), v))
+		for _, k := range this.Keys() {
+			items = append(items, NewList(this.KeyOf(k), this.Elements[k]))
 		}
 		return NewList(items...)
 	},
@@ -358,20 +381,19 @@ var Dict_Items = F(
 var Dict_Elements = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*Dict)
-		keys := []string{}
-		for k := range this.Elements {
-			keys = append(keys, k)
-		}
+		keys := this.Keys()
 
 		idx := 0
-		return NewInternalStream(func(s *Scope) Object {
+		stream := NewInternalStream(func(s *Scope) Object {
 			if idx >= len(keys) {
 				return nil
 			}
 			k := keys[idx]
 			idx++
-			return YieldWith(NewTuple(this.Elements[k], NewString(k)))
+			return YieldWith(NewTuple(this.Elements[k], this.KeyOf(k)))
 		}, scope)
+		stream.Indexed = true
+		return stream
 	},
 	`Elements`,
 	`Returns a stream of all key-value pairs in the dictionary.`,
@@ -64,6 +64,7 @@ func TestDict_Concat(t *testing.T) {
 	common.AssertCode(t, `a := { key=44 }; b := { key2=45 }; a.Concat(b); b`, "{key2=45}")
 	common.AssertCode(t, `a := { key=44 }; b := { key2=45 }; a.Concat(b); a.Size()`, "2")
 	common.AssertCode(t, `a := { key=44 }; b := { key=45 }; a.Concat(b); a`, "{key=45}")
+	common.AssertCode(t, `a := { x=1, y=2 }; a.Concat({ z=3 }, { x=4 }).Keys()`, "['x', 'y', 'z']")
 }
 
 func TestDict_Find(t *testing.T) {
@@ -111,6 +112,8 @@ func TestDict_CountFn(t *testing.T) {
 func TestDict_Keys(t *testing.T) {
 	common.AssertCode(t, `a := { x=1, y=2, z=3 }; a.Keys().Sorted()`, "['x', 'y', 'z']")
 	common.AssertCode(t, `a := { }; a.Keys()`, "[]")
+	common.AssertCode(t, `a := { z=1, x=2 }; a['y'] = 3; a.Keys()`, "['z', 'x', 'y']")
+	common.AssertCode(t, `a := { z=1, x=2 }; a.Remove('z'); a['z'] = 3; a`, "{x=2, z=3}")
 }
 
 func TestDict_Values(t *testing.T) {
@@ -121,3 +124,16 @@ func TestDict_Values(t *testing.T) {
 func TestDict_Items(t *testing.T) {
 	common.AssertCode(t, `a := { }; a.Items()`, "[]")
 }
+
+func TestDict_StructuralKeys(t *testing.T) {
+	common.AssertCode(t, `a := {}; a[[1, 2]] = 'x'; a[[1, 2]]`, "x")
+	common.AssertCode(t, `a := {}; a.Set((1, 'b'), 'x'); a.Has((1, 'b'))`, "true")
+	common.AssertCode(t, `a := {}; a.Set((1, 'b'), 'x'); a.Has((1, 'c'))`, "false")
+	common.AssertCode(t, `data P { x = 0 }; a := {}; a[P { x=1 }] = 'x'; a.GetOr(P { x=1 }, 'y')`, "x")
+	common.AssertCode(t, `a := {}; a[[1, 2]] = 'x'; a.Has('[1, 2]')`, "false")
+	common.AssertCode(t, `a := {}; a[[1, 2]] = 'x'; a['b'] = 'y'; a.Keys()`, "[[1, 2], 'b']")
+	common.AssertCode(t, `a := {}; a.Set((1, 'b'), 'x'); a.Items()`, "[[(1, 'b'), 'x']]")
+	common.AssertCode(t, `a := {}; a[[1]] = 'x'; a.Copy().Keys()[0] == [1]`, "true")
+	common.AssertCode(t, `a := {}; a[3] = 'x'; [a['3'], a.Keys()]`, "['x', [3]]")
+	common.AssertCode(t, `([1, 2, 3] | groupBy (x: [x % 2])).Keys()`, "[[1], [0]]")
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -50,6 +51,8 @@ func (o *DictType) Convert(scope *Scope, obj Object) Object {
 type Dict struct {
 	*BaseObject
 	Elements map[string]Object
+	order    []string          // keys in insertion order, see Keys
+	keys     map[string]Object // key objects that are not their own hash key, see KeyOf
 }
 
 func NewDict(elements map[string]Object) *Dict {
@@ -69,12 +72,78 @@ func NewDictFromList(e ...Object) *Dict {
 	for i := 0; i < len(e); i += 2 {
 		key := e[i].AsString()
 		value := e[i+1]
-		d.Elements[key] = value
+		d.Set(key, value)
 	}
 
 	return d
 }
 
+// Set sets the element, keeping the position of the key if it already exists.
+func (o *Dict) Set(key string, value Object) {
+	if _, ok := o.Elements[key]; !ok {
+		o.order = append(o.order, key)
+	}
+	o.Elements[key] = value
+}
+
+// SetKey sets the element of a key of any type, given its hash key (see
+// HashKey), remembering the key object so KeyOf returns it.
+func (o *Dict) SetKey(hash string, key Object, value Object) {
+	_, exists := o.Elements[hash]
+	if s, ok := key.(*String); !exists && (!ok || s.Value != hash) {
+		if o.keys == nil {
+			o.keys = map[string]Object{}
+		}
+		o.keys[hash] = key
+	}
+	o.Set(hash, value)
+}
+
+// KeyOf returns the key object of the hash key, as given to SetKey.
+func (o *Dict) KeyOf(hash string) Object {
+	if key, ok := o.keys[hash]; ok {
+		return key
+	}
+	return NewString(hash)
+}
+
+// Delete removes the element, if it exists.
+func (o *Dict) Delete(key string) {
+	if _, ok := o.Elements[key]; !ok {
+		return
+	}
+	delete(o.Elements, key)
+	delete(o.keys, key)
+	if i := slices.Index(o.order, key); i >= 0 {
+		o.order = slices.Delete(o.order, i, i+1)
+	}
+}
+
+// Keys returns the keys in insertion order. Keys added directly to Elements,
+// without Set, come after the others, sorted.
+func (o *Dict) Keys() []string {
+	keys := make([]string, 0, len(o.Elements))
+	seen := make(map[string]bool, len(o.Elements))
+	for _, key := range o.order {
+		if _, ok := o.Elements[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	if len(keys) == len(o.Elements) {
+		return keys
+	}
+
+	var rest []string
+	for key := range o.Elements {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+	return append(keys, rest...)
+}
+
 func (o *Dict) OnIndex(scope *Scope, t *Tuple) Object {
 	if len(t.Elements) == 1 {
 		return Dict_Get.Call(scope, o, t.Elements[0])
@@ -89,18 +158,26 @@ func (o *Dict) OnIndexAssign(scope *Scope, t *Tuple, value Object) Object {
 	return scope.Interrupt(Raise("invalid string index '%s'", t.AsString()))
 }
 
+func (o *Dict) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *Dict) AsBool() bool {
 	return true
 }
 
 func (o *Dict) AsString() string {
 	var elements []string
-	for k, v := range o.Elements {
-		elements = append(elements, fmt.Sprintf("%s=%s", k, v.AsString()))
+	for _, k := range o.Keys() {
+		elements = append(elements, fmt.Sprintf("%s=%s", o.KeyOf(k).AsString(), o.Elements[k].AsString()))
 	}
 	return fmt.Sprintf("{%s}", strings.Join(elements, ", "))
 }
 
+func (o *Dict) AsRepr() string {
+	return o.AsString()
+}
+
 func (o *Dict) AsInterface() any {
 	return o.AsString()
 }
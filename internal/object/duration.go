@@ -0,0 +1,260 @@
+package object
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var DurationId = TypeIdentifier("Duration")
+var DurationTypeObj = NewDurationType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type DurationType struct {
+	*BaseObjectType
+}
+
+func NewDurationType() *DurationType {
+	return &DurationType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			DurationId,
+		),
+	}
+}
+
+func init() {
+	DurationTypeObj.AddMethod(Duration_Milliseconds)
+	DurationTypeObj.AddMethod(Duration_Seconds)
+	DurationTypeObj.AddMethod(Duration_Minutes)
+	DurationTypeObj.AddMethod(Duration_Hours)
+	DurationTypeObj.AddMethod(Duration_Truncate)
+	DurationTypeObj.AddMethod(Duration_Round)
+	DurationTypeObj.AddMethod(Duration_Abs)
+
+	DurationTypeObj.SetProperty("Millisecond", NewDuration(time.Millisecond))
+	DurationTypeObj.SetProperty("Second", NewDuration(time.Second))
+	DurationTypeObj.SetProperty("Minute", NewDuration(time.Minute))
+	DurationTypeObj.SetProperty("Hour", NewDuration(time.Hour))
+	DurationTypeObj.SetProperty("Day", NewDuration(24*time.Hour))
+}
+
+func (o *DurationType) Instantiate(scope *Scope) Object {
+	return NewDuration(0)
+}
+
+// Convert accepts a number of milliseconds, like the time-aware functions, or
+// a string like '1h30m', '250ms' or '2d'.
+func (o *DurationType) Convert(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *Duration:
+		return obj
+	case *Number:
+		return NewDuration(time.Duration(obj.Value * float64(time.Millisecond)))
+	case *String:
+		d, err := parseDuration(obj.Value)
+		if err != nil {
+			return scope.Interrupt(Raise("invalid duration '%s': %s", obj.Value, err.Error()))
+		}
+		return NewDuration(d)
+	}
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Duration'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// Duration is an elapsed time with nanosecond precision, the difference
+// between two instants.
+type Duration struct {
+	*BaseObject
+	Value time.Duration
+}
+
+func NewDuration(d time.Duration) *Duration {
+	return &Duration{
+		BaseObject: NewBaseObject(DurationTypeObj),
+		Value:      d,
+	}
+}
+
+// OnOperator adds and subtracts durations, scales them by numbers and
+// divides them by other durations, returning the ratio.
+func (o *Duration) OnOperator(scope *Scope, op string, right Object) Object {
+	switch right := right.(type) {
+	case *Duration:
+		switch op {
+		case "+":
+			return NewDuration(o.Value + right.Value)
+		case "-":
+			return NewDuration(o.Value - right.Value)
+		case "/":
+			return NewNumber(float64(o.Value) / float64(right.Value))
+		}
+
+	case *Number:
+		switch op {
+		case "*":
+			return NewDuration(time.Duration(float64(o.Value) * right.Value))
+		case "/":
+			return NewDuration(time.Duration(float64(o.Value) / right.Value))
+		}
+	}
+
+	if err := operandError(scope, op, o, right); err != nil {
+		return err
+	}
+	return OrderOperator(scope, op, o, right)
+}
+
+// Raises for an arithmetic operator the left operand supports, but not with
+// the type of the right one. Returns nil for the other operators.
+func operandError(scope *Scope, op string, left, right Object) Object {
+	switch op {
+	case "+":
+		return scope.Interrupt(Raise("cannot add '%s' to '%s'", right.TypeId(), left.TypeId()))
+	case "-":
+		return scope.Interrupt(Raise("cannot subtract '%s' from '%s'", right.TypeId(), left.TypeId()))
+	case "*":
+		return scope.Interrupt(Raise("cannot multiply '%s' by '%s'", left.TypeId(), right.TypeId()))
+	case "/":
+		return scope.Interrupt(Raise("cannot divide '%s' by '%s'", left.TypeId(), right.TypeId()))
+	}
+	return nil
+}
+
+func (o *Duration) AsBool() bool {
+	return o.Value != 0
+}
+
+func (o *Duration) AsString() string {
+	return o.Value.String()
+}
+
+func (o *Duration) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Duration) AsInterface() any {
+	return o.Value
+}
+
+// Parses a duration like Go does, also accepting days, as in '2d12h'.
+func parseDuration(s string) (time.Duration, error) {
+	text := strings.TrimSpace(s)
+	days := 0.0
+	if i := strings.Index(text, "d"); i >= 0 {
+		sign := 1.0
+		number := text[:i]
+		if strings.HasPrefix(number, "-") {
+			sign, number = -1, number[1:]
+		}
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return 0, errors.New("expected a number of days")
+		}
+		days, text = sign*n, text[i+1:]
+		if text == "" {
+			return time.Duration(days * float64(24*time.Hour)), nil
+		}
+		if days < 0 {
+			text = "-" + text
+		}
+	}
+
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, errors.New("expected a duration like '1h30m', '250ms' or '2d'")
+	}
+	return time.Duration(days*float64(24*time.Hour)) + d, nil
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Duration_Milliseconds = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		return NewNumber(float64(this.Value) / float64(time.Millisecond))
+	},
+	`Milliseconds`,
+	`Returns the duration as a number of milliseconds, the unit used by the time-aware functions.`,
+	P("this", V.Type(DurationId)),
+)
+
+var Duration_Seconds = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		return NewNumber(this.Value.Seconds())
+	},
+	`Seconds`,
+	`Returns the duration as a number of seconds, with the fraction.`,
+	P("this", V.Type(DurationId)),
+)
+
+var Duration_Minutes = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		return NewNumber(this.Value.Minutes())
+	},
+	`Minutes`,
+	`Returns the duration as a number of minutes, with the fraction.`,
+	P("this", V.Type(DurationId)),
+)
+
+var Duration_Hours = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		return NewNumber(this.Value.Hours())
+	},
+	`Hours`,
+	`Returns the duration as a number of hours, with the fraction.`,
+	P("this", V.Type(DurationId)),
+)
+
+var Duration_Truncate = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewDuration(this.Value.Truncate(d))
+	},
+	`Truncate`,
+	`Returns the duration rounded towards zero to a multiple of the other.`,
+	P("this", V.Type(DurationId)),
+	P("multiple"),
+)
+
+var Duration_Round = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewDuration(this.Value.Round(d))
+	},
+	`Round`,
+	`Returns the duration rounded to the nearest multiple of the other, halfway values away from zero.`,
+	P("this", V.Type(DurationId)),
+	P("multiple"),
+)
+
+var Duration_Abs = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Duration)
+		return NewDuration(this.Value.Abs())
+	},
+	`Abs`,
+	`Returns the absolute value of the duration.`,
+	P("this", V.Type(DurationId)),
+)
@@ -0,0 +1,42 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestDuration_Convert(t *testing.T) {
+	common.AssertCode(t, ` Duration(1500)`, `1.5s`)
+	common.AssertCode(t, ` Duration('1h30m')`, `1h30m0s`)
+	common.AssertCode(t, ` Duration('2d12h')`, `60h0m0s`)
+	common.AssertCode(t, ` Duration('-1d')`, `-24h0m0s`)
+	common.AssertCode(t, ` Duration {}`, `0s`)
+	common.AssertCode(t, ` Duration.Hour`, `1h0m0s`)
+	common.AssertCodeError(t, ` Duration('soon')`)
+	common.AssertCodeError(t, ` Duration([])`)
+}
+
+func TestDuration_Operators(t *testing.T) {
+	common.AssertCode(t, ` Duration.Hour + Duration.Minute * 30`, `1h30m0s`)
+	common.AssertCode(t, ` Duration.Hour - Duration('90m')`, `-30m0s`)
+	common.AssertCode(t, ` Duration.Hour / 4`, `15m0s`)
+	common.AssertCode(t, ` Duration.Day / Duration.Hour`, `24`)
+	common.AssertCode(t, ` [Duration.Hour > Duration.Minute, Duration('60m') == Duration.Hour]`, `[true, true]`)
+	common.AssertCode(t, ` [Duration.Hour, Duration.Second, Duration.Minute] | sortBy(fn (x) { x })`, `[1s, 1m0s, 1h0m0s]`)
+	common.AssertCodeError(t, ` Duration.Hour + 1`)
+	common.AssertCodeError(t, ` Duration.Hour < 1`)
+	common.AssertCode(t, ` (Duration.Hour - 1)?.Error()`, `cannot subtract 'Number' from 'Duration'`)
+	common.AssertCode(t, ` (Duration.Hour * Duration.Hour)?.Error()`, `cannot multiply 'Duration' by 'Duration'`)
+	common.AssertCode(t, ` (Duration.Hour / 'x')?.Error()`, `cannot divide 'Duration' by 'String'`)
+}
+
+func TestDuration_Methods(t *testing.T) {
+	common.AssertCode(t, ` Duration('1h30m').Hours()`, `1.500000`)
+	common.AssertCode(t, ` Duration('1h30m').Minutes()`, `90`)
+	common.AssertCode(t, ` Duration('1m30s').Seconds()`, `90`)
+	common.AssertCode(t, ` Duration('1.5s').Milliseconds()`, `1500`)
+	common.AssertCode(t, ` Duration('1h47m').Truncate(Duration('15m'))`, `1h45m0s`)
+	common.AssertCode(t, ` Duration('1h53m').Round(Duration.Hour)`, `2h0m0s`)
+	common.AssertCode(t, ` Duration('-3s').Abs()`, `3s`)
+}
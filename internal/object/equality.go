@@ -0,0 +1,353 @@
+package object
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Structural Equality - used by `==`, `!=`, `<=>`, match patterns and dict
+// keys. Data types may override the default behavior by defining the methods
+// `Equals(this, other)`, `Compare(this, other)` and `Hash(this)`.
+// ----------------------------------------------------------------------------
+
+// Equals compares two objects structurally, returning a Boolean or a raise.
+func Equals(scope *Scope, a, b Object) Object {
+	if a.Id() == b.Id() {
+		return True
+	}
+
+	// The override of either side is used, so `a == b` and `b == a` agree
+	for _, pair := range [][2]Object{{a, b}, {b, a}} {
+		if data, ok := pair[0].(*Data); ok {
+			if fn := data.Type().GetProperty("Equals"); fn != nil {
+				ret := scope.Eval().Call(scope, fn, []Object{pair[0], pair[1]})
+				if isRaise(ret) {
+					return ret
+				}
+				return NewBoolean(ret.AsBool())
+			}
+		}
+	}
+
+	if a.TypeId() != b.TypeId() {
+		return False
+	}
+
+	switch a := a.(type) {
+	case *Number:
+		return NewBoolean(a.Value == b.(*Number).Value)
+
+	case *Boolean:
+		return NewBoolean(a.Value == b.(*Boolean).Value)
+
+	case *String:
+		return NewBoolean(a.Value == b.(*String).Value)
+
+	case *List:
+		return equalsElements(scope, a.Elements, b.(*List).Elements)
+
+	case *Tuple:
+		return equalsElements(scope, a.Elements, b.(*Tuple).Elements)
+
+	case *Dict:
+		other := b.(*Dict)
+		if len(a.Elements) != len(other.Elements) {
+			return False
+		}
+
+		for key, value := range a.Elements {
+			otherValue, ok := other.Elements[key]
+			if !ok {
+				return False
+			}
+
+			res := Equals(scope, value, otherValue)
+			if isRaise(res) || !res.AsBool() {
+				return res
+			}
+		}
+		return True
+
+	case *Maybe:
+		other := b.(*Maybe)
+		if a.Ok != other.Ok {
+			return False
+		}
+		return Equals(scope, a.Result(), other.Result())
+
+	case *Error:
+		return NewBoolean(a.Message == b.(*Error).Message)
+
+	case *Time:
+		return NewBoolean(a.Value.Equal(b.(*Time).Value))
+
+	case *Duration:
+		return NewBoolean(a.Value == b.(*Duration).Value)
+
+	case *Data:
+		tp := a.Type().(*DataType)
+		if tp.Id() != b.Type().Id() {
+			return False
+		}
+
+		for _, name := range tp.AttributeNames() {
+			res := Equals(scope, a.GetProperty(name), b.GetProperty(name))
+			if isRaise(res) || !res.AsBool() {
+				return res
+			}
+		}
+		return True
+	}
+
+	return False
+}
+
+// Compare orders two objects, returning -1, 0 or 1 as a Number, or a raise if
+// the objects cannot be ordered.
+func Compare(scope *Scope, a, b Object) Object {
+	if data, ok := a.(*Data); ok {
+		if fn := data.Type().GetProperty("Compare"); fn != nil {
+			return scope.Eval().Call(scope, fn, []Object{a, b})
+		}
+	}
+
+	if a.TypeId() != b.TypeId() {
+		return scope.Interrupt(Raise("types incompatible for comparison '%s' and '%s'", a.TypeId(), b.TypeId()))
+	}
+
+	switch a := a.(type) {
+	case *List:
+		return compareElements(scope, a.Elements, b.(*List).Elements)
+
+	case *Tuple:
+		return compareElements(scope, a.Elements, b.(*Tuple).Elements)
+
+	case *Number, *String:
+		return scope.Eval().Operator(scope, "<=>", a, b)
+
+	case *Time:
+		return NewNumber(float64(a.Value.Compare(b.(*Time).Value)))
+
+	case *Duration:
+		x := a.Value
+		y := b.(*Duration).Value
+		switch {
+		case x == y:
+			return Zero
+		case x < y:
+			return MinusOne
+		}
+		return One
+
+	case *Boolean:
+		x := a.Value
+		y := b.(*Boolean).Value
+		switch {
+		case x == y:
+			return Zero
+		case !x:
+			return MinusOne
+		}
+		return One
+	}
+
+	return scope.Interrupt(Raise("type '%s' does not support ordering", a.TypeId()))
+}
+
+// HashKey returns the canonical key of an object, used to index dicts and to
+// compute hashes. Objects that are structurally equal share the same key.
+//
+// Strings are their own keys, so dicts keyed by names are easy to use from Go.
+// Numbers and booleans use their string representation on purpose, so `d[3]`
+// and `d['3']` keep referring to the same entry. Keys of the other types start
+// with hashPrefix, which is doubled in the strings starting with it, so they
+// never collide with strings.
+func HashKey(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *String:
+		if strings.HasPrefix(obj.Value, hashPrefix) {
+			return NewString(hashPrefix + obj.Value)
+		}
+		return obj
+
+	case *Number, *Boolean:
+		return NewString(obj.AsString())
+	}
+
+	key, err := hashKey(scope, obj)
+	if err != nil {
+		return err
+	}
+	return NewString(hashPrefix + key)
+}
+
+const hashPrefix = "\x00"
+
+// OrderOperator resolves the ordering operators (`<`, `>`, `<=`, `>=` and
+// `<=>`) using Compare.
+func OrderOperator(scope *Scope, op string, left, right Object) Object {
+	switch op {
+	case "==":
+		return Equals(scope, left, right)
+
+	case "!=":
+		res := Equals(scope, left, right)
+		if isRaise(res) {
+			return res
+		}
+		return NewBoolean(!res.AsBool())
+
+	case "<=>", "<", ">", "<=", ">=":
+		// resolved by Compare below
+
+	default:
+		return scope.Interrupt(Raise("type '%s' does not support operator '%s'", left.TypeId(), op))
+	}
+
+	res := Compare(scope, left, right)
+	if isRaise(res) {
+		return res
+	}
+
+	cmp := res.(*Number).Value
+	switch op {
+	case "<=>":
+		return res
+	case "<":
+		return NewBoolean(cmp < 0)
+	case ">":
+		return NewBoolean(cmp > 0)
+	case "<=":
+		return NewBoolean(cmp <= 0)
+	default:
+		return NewBoolean(cmp >= 0)
+	}
+}
+
+func equalsElements(scope *Scope, a, b []Object) Object {
+	if len(a) != len(b) {
+		return False
+	}
+
+	for i := range a {
+		res := Equals(scope, a[i], b[i])
+		if isRaise(res) || !res.AsBool() {
+			return res
+		}
+	}
+	return True
+}
+
+func compareElements(scope *Scope, a, b []Object) Object {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		res := Compare(scope, a[i], b[i])
+		if isRaise(res) {
+			return res
+		}
+
+		if res.(*Number).Value != 0 {
+			return res
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return MinusOne
+	case len(a) > len(b):
+		return One
+	}
+	return Zero
+}
+
+func hashKey(scope *Scope, obj Object) (string, Object) {
+	switch obj := obj.(type) {
+	case *Number:
+		return obj.AsString(), nil
+
+	case *Boolean:
+		return obj.AsString(), nil
+
+	case *String:
+		return strconv.Quote(obj.Value), nil
+
+	case *List:
+		key, err := hashElements(scope, obj.Elements)
+		return "[" + key + "]", err
+
+	case *Tuple:
+		key, err := hashElements(scope, obj.Elements)
+		return "(" + key + ")", err
+
+	case *Dict:
+		keys := make([]string, 0, len(obj.Elements))
+		for key := range obj.Elements {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			value, err := hashKey(scope, obj.Elements[key])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = strconv.Quote(key) + "=" + value
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+
+	case *Maybe:
+		value, err := hashKey(scope, obj.Result())
+		if obj.Ok {
+			return "Maybe(ok, " + value + ")", err
+		}
+		return "Maybe(error, " + value + ")", err
+
+	case *Error:
+		return "Error(" + strconv.Quote(obj.Message) + ")", nil
+
+	case *Time:
+		return "Time(" + obj.Value.UTC().Format(time.RFC3339Nano) + ")", nil
+
+	case *Duration:
+		return "Duration(" + strconv.FormatInt(int64(obj.Value), 10) + ")", nil
+
+	case *Data:
+		tp := obj.Type().(*DataType)
+		if fn := tp.GetProperty("Hash"); fn != nil {
+			ret := scope.Eval().Call(scope, fn, []Object{obj})
+			if isRaise(ret) {
+				return "", ret
+			}
+			return fmt.Sprintf("%s#%s(%s)", tp.Name, tp.Id(), ret.AsString()), nil
+		}
+
+		names := tp.AttributeNames()
+		parts := make([]string, len(names))
+		for i, name := range names {
+			value, err := hashKey(scope, obj.GetProperty(name))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = name + "=" + value
+		}
+		return fmt.Sprintf("%s#%s{%s}", tp.Name, tp.Id(), strings.Join(parts, ", ")), nil
+	}
+
+	return fmt.Sprintf("<%s#%s>", obj.TypeId(), obj.Id()), nil
+}
+
+func hashElements(scope *Scope, elements []Object) (string, Object) {
+	parts := make([]string, len(elements))
+	for i, e := range elements {
+		key, err := hashKey(scope, e)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = key
+	}
+	return strings.Join(parts, ", "), nil
+}
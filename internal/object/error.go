@@ -69,6 +69,10 @@ func (o *Error) AsInterface() any {
 	return o.AsString()
 }
 
+func (o *Error) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *Error) AsRepr() string {
 	return o.AsString()
 }
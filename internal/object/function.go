@@ -43,6 +43,7 @@ type Function struct {
 	Name       string
 	Parameters []ast.Node
 	Body       ast.Node
+	ReturnType ast.Node
 	Scope      *Scope
 }
 
@@ -99,6 +100,17 @@ func F(fn BuiltinFn, name, docs string, params ...*Param) *BuiltinFunction {
 	return b
 }
 
+// Bind returns a copy of the function with the given parent, the `this` of
+// method calls. Builtin functions are shared by every runtime, so they are not
+// changed in place.
+func (o *BuiltinFunction) Bind(parent Object) *BuiltinFunction {
+	base := *o.BaseObject
+	base.parent = parent
+	b := *o
+	b.BaseObject = &base
+	return &b
+}
+
 func (o *BuiltinFunction) AsBool() bool {
 	return true
 }
@@ -249,7 +249,7 @@ var List_SplitAt = F(
 var List_SplitFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 
 		result := NewList()
 		sublist := NewList()
@@ -312,7 +312,7 @@ var List_Sort = F(
 var List_SortedFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		sort.Slice(this.Elements, func(i, j int) bool {
 			ret := scope.Eval().Call(scope, f, []Object{this.Elements[i], this.Elements[j]})
 			if isRaise(ret) {
@@ -471,7 +471,7 @@ var List_Find = F(
 var List_FindFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		for i, e := range this.Elements {
 			ret := scope.Eval().Call(scope, f, []Object{e, NewNumber(float64(i))})
 			if isRaise(ret) {
@@ -514,7 +514,7 @@ var List_FindLast = F(
 var List_FindLastFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		for i := len(this.Elements) - 1; i >= 0; i-- {
 			e := this.Elements[i]
 			ret := scope.Eval().Call(scope, f, []Object{e, NewNumber(float64(i))})
@@ -559,7 +559,7 @@ var List_FindAll = F(
 var List_FindAllFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		result := NewList()
 		for i, e := range this.Elements {
 			ret := scope.Eval().Call(scope, f, []Object{e, NewNumber(float64(i))})
@@ -613,7 +613,7 @@ var List_Contains = F(
 var List_ContainsFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		for _, e := range this.Elements {
 			ret := scope.Eval().Call(scope, f, []Object{e})
 			if isRaise(ret) {
@@ -667,7 +667,7 @@ var List_Count = F(
 var List_CountFn = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
-		f := args[1].(*Function)
+		f := args[1]
 		count := 0
 		for _, e := range this.Elements {
 			ret := scope.Eval().Call(scope, f, []Object{e})
@@ -711,7 +711,7 @@ var List_Elements = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*List)
 		idx := 0
-		return NewInternalStream(func(s *Scope) Object {
+		stream := NewInternalStream(func(s *Scope) Object {
 			if idx >= len(this.Elements) {
 				return nil
 			}
@@ -719,6 +719,8 @@ var List_Elements = F(
 			idx++
 			return YieldWith(NewTuple(e, NewNumber(float64(idx-1))))
 		}, scope)
+		stream.Indexed = true
+		return stream
 	},
 	`Elements`,
 	`Returns a stream of the elements in the list.`,
@@ -2,6 +2,7 @@ package object
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -41,13 +42,8 @@ func (o *ListType) Convert(scope *Scope, obj Object) Object {
 
 	case *Stream:
 		result := []Object{}
-		ret := obj.Resolve(func(obj Object) Object {
-			switch obj := obj.(type) {
-			case *Tuple:
-				result = append(result, obj.Elements[0])
-			default:
-				result = append(result, obj)
-			}
+		ret := obj.Resolve(func(value Object) Object {
+			result = append(result, streamValue(obj, value))
 			return nil
 		})
 		if isRaise(ret) {
@@ -77,6 +73,14 @@ func NewList(e ...Object) *List {
 
 func (o *List) OnIndex(scope *Scope, t *Tuple) Object {
 	if len(t.Elements) == 1 {
+		if slice, ok := t.Elements[0].(*Slice); ok {
+			elements, err := selectSlice(scope, slice, o.Elements)
+			if err != nil {
+				return err
+			}
+			return NewList(elements...)
+		}
+
 		return List_Get.Call(scope, o, t.Elements[0])
 	} else if len(t.Elements) == 2 {
 		return List_Sub.Call(scope, o, t.Elements[0], t.Elements[1])
@@ -86,12 +90,50 @@ func (o *List) OnIndex(scope *Scope, t *Tuple) Object {
 
 func (o *List) OnIndexAssign(scope *Scope, t *Tuple, value Object) Object {
 	if len(t.Elements) == 1 {
+		if slice, ok := t.Elements[0].(*Slice); ok {
+			return o.assignSlice(scope, slice, value)
+		}
+
 		return List_Set.Call(scope, o, t.Elements[0], value)
 	}
 
 	return scope.Interrupt(Raise("invalid string index '%s'", t.AsString()))
 }
 
+// Replaces the elements selected by the slice with the elements of value. A
+// contiguous slice may change the size of the list, while a stepped slice
+// requires the same number of elements.
+func (o *List) assignSlice(scope *Scope, slice *Slice, value Object) Object {
+	var values []Object
+	switch value := value.(type) {
+	case *List:
+		values = slices.Clone(value.Elements)
+	case *Tuple:
+		values = slices.Clone(value.Elements)
+	default:
+		return scope.Interrupt(Raise("can only assign a List or Tuple to a slice, got '%s'", value.TypeId()))
+	}
+
+	if slice.IsContiguous() {
+		start, stop := slice.Bounds(len(o.Elements))
+		o.Elements = slices.Concat(o.Elements[:start], values, o.Elements[stop:])
+		return value
+	}
+
+	indices, err := slice.Indices(scope, len(o.Elements))
+	if err != nil {
+		return err
+	}
+
+	if len(indices) != len(values) {
+		return scope.Interrupt(Raise("cannot assign %d elements to a slice of size %d", len(values), len(indices)))
+	}
+	for i, idx := range indices {
+		o.Elements[idx] = values[i]
+	}
+	return value
+}
+
 func (o *List) AsBool() bool {
 	return true
 }
@@ -108,6 +150,10 @@ func (o *List) AsInterface() any {
 	return o.AsString()
 }
 
+func (o *List) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *List) AsRepr() string {
 	return o.AsString()
 }
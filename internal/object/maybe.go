@@ -117,6 +117,10 @@ func (o *Maybe) Result() Object {
 	return o.Error
 }
 
+func (o *Maybe) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *Maybe) AsRepr() string {
 	return o.AsString()
 }
@@ -0,0 +1,491 @@
+package object
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var Module_Csv = NewModuleType("Csv")
+
+func init() {
+	Module_Csv.AddMethod(Module_Csv_Read)
+	Module_Csv.AddMethod(Module_Csv_Write)
+}
+
+// ----------------------------------------------------------------------------
+// Options of Read:
+//
+// - header: true to use the first record as the keys (default), false to
+//   yield lists, or a list with the keys;
+// - delimiter: the field separator, `,` by default;
+// - quote: the quote character, `"` by default, or '' to disable quoting;
+// - comment: lines starting with it are skipped;
+// - infer: true to convert numbers and booleans (default), false to keep
+//   all fields as strings.
+//
+// Options of Write: header (default true), delimiter and columns, the list of
+// keys to write, in order.
+// ----------------------------------------------------------------------------
+
+var Module_Csv_Read = F(
+	func(scope *Scope, args ...Object) Object {
+		opts := &csvReadOptions{header: true, delimiter: ',', quote: '"', infer: true}
+		if len(args) > 1 {
+			if err := opts.set(scope, args[1]); err != nil {
+				return err
+			}
+		}
+
+		var reader *csvReader
+		var lines *streamReader
+		var closeSource func(*Scope) Object
+		switch source := args[0].(type) {
+		case *String:
+			var file *os.File
+			var err error
+			scope.Blocking(func() {
+				file, err = os.Open(source.Value)
+			})
+			if err != nil {
+				return fileError(scope, err)
+			}
+			reader = newCsvReader(scope, file, opts, true)
+			closeSource = func(*Scope) Object {
+				file.Close()
+				return nil
+			}
+
+		default:
+			s := StreamTypeObj.Convert(scope, source)
+			if isRaise(s) {
+				return s
+			}
+			stream := s.(*Stream)
+			lines = &streamReader{scope: scope, stream: stream}
+			reader = newCsvReader(scope, lines, opts, false)
+			closeSource = stream.Close
+		}
+
+		keys := opts.keys
+		return NewInternalStream(func(s *Scope) Object {
+			for {
+				fields, err := reader.read()
+				switch {
+				case lines != nil && lines.err != nil:
+					return lines.err
+				case err == io.EOF:
+					return nil
+				case err != nil:
+					return s.Interrupt(Raise("invalid csv at line %d: %s", reader.line, err.Error()))
+				}
+
+				if opts.header && keys == nil {
+					keys = fields
+					continue
+				}
+
+				values := make([]Object, len(fields))
+				for i, field := range fields {
+					values[i] = csvValue(field, opts.infer)
+				}
+				if !opts.header {
+					return YieldWith(NewList(values...))
+				}
+
+				if len(values) != len(keys) {
+					return s.Interrupt(Raise("invalid csv at line %d: expected %d fields, got %d", reader.line, len(keys), len(values)))
+				}
+				record := NewDict(map[string]Object{})
+				for i, key := range keys {
+					record.Set(key, values[i])
+				}
+				return YieldWith(record)
+			}
+		}, scope).OnClose(closeSource)
+	},
+	"Read",
+	"Returns a stream of the records of a CSV file, given by its path or as a stream of lines. Records are dicts keyed by the header, or lists if there is no header.",
+	P("source"),
+	P("options"),
+)
+
+var Module_Csv_Write = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		header := true
+		var columns []string
+		delimiter := ','
+		if len(args) > 2 {
+			opts, ok := args[2].(*Dict)
+			if !ok {
+				return scope.Interrupt(Raise("expected a dict of options, got '%s'", args[2].TypeId()))
+			}
+			for key, value := range opts.Elements {
+				switch key {
+				case "header":
+					header = value.AsBool()
+				case "delimiter":
+					r, err := csvRune(scope, key, value)
+					if err != nil {
+						return err
+					}
+					delimiter = r
+				case "columns":
+					list := ListTypeObj.Convert(scope, value)
+					if isRaise(list) {
+						return list
+					}
+					for _, column := range list.(*List).Elements {
+						columns = append(columns, column.AsString())
+					}
+				default:
+					return scope.Interrupt(Raise("unknown option '%s', expected header, delimiter or columns", key))
+				}
+			}
+		}
+
+		path := args[1].AsString()
+		var file *os.File
+		var err error
+		scope.Blocking(func() {
+			file, err = os.Create(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		writer := csv.NewWriter(bufio.NewWriter(file))
+		writer.Comma = delimiter
+
+		count := 0
+		ret := stream.Resolve(func(value Object) Object {
+			value = streamValue(stream, value)
+
+			var fields []string
+			switch record := value.(type) {
+			case *List, *Tuple:
+				for _, e := range valuesOfRecord(record) {
+					fields = append(fields, csvField(e))
+				}
+
+			case *Dict, *Data:
+				dict := recordToDict(scope, record).(*Dict)
+				if columns == nil {
+					columns = dict.Keys()
+				}
+				fields = make([]string, len(columns))
+				for i, column := range columns {
+					if v, ok := dict.Elements[column]; ok {
+						fields[i] = csvField(v)
+					}
+				}
+
+			default:
+				return scope.Interrupt(Raise("expected a dict, data or list record, got '%s'", value.TypeId()))
+			}
+
+			scope.Blocking(func() {
+				if count == 0 && header && columns != nil {
+					if err = writer.Write(columns); err != nil {
+						return
+					}
+				}
+				err = writer.Write(fields)
+			})
+			if err != nil {
+				return fileError(scope, err)
+			}
+			count++
+			return nil
+		})
+
+		scope.Blocking(func() {
+			writer.Flush()
+			err = errors.Join(writer.Error(), file.Close())
+		})
+		if isRaise(ret) {
+			return ret
+		}
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewNumber(float64(count))
+	},
+	"Write",
+	"Writes each record of the stream as a line of the CSV file, replacing it if it exists. The header comes from the keys of the first record, unless the columns are given. Returns the number of records written.",
+	P("stream"),
+	P("path", V.Type(StringId)),
+	P("options"),
+)
+
+type csvReadOptions struct {
+	header    bool
+	keys      []string // given by the header option
+	delimiter rune
+	quote     rune // 0 if quoting is disabled
+	comment   rune
+	infer     bool
+}
+
+func (opts *csvReadOptions) set(scope *Scope, obj Object) Object {
+	dict, ok := obj.(*Dict)
+	if !ok {
+		return scope.Interrupt(Raise("expected a dict of options, got '%s'", obj.TypeId()))
+	}
+
+	for key, value := range dict.Elements {
+		var err Object
+		switch key {
+		case "header":
+			if list, ok := value.(*List); ok {
+				for _, e := range list.Elements {
+					opts.keys = append(opts.keys, e.AsString())
+				}
+				opts.header = true
+			} else {
+				opts.header = value.AsBool()
+			}
+		case "delimiter":
+			opts.delimiter, err = csvRune(scope, key, value)
+		case "quote":
+			if value.AsString() == "" {
+				opts.quote = 0
+			} else {
+				opts.quote, err = csvRune(scope, key, value)
+			}
+		case "comment":
+			opts.comment, err = csvRune(scope, key, value)
+		case "infer":
+			opts.infer = value.AsBool()
+		default:
+			err = scope.Interrupt(Raise("unknown option '%s', expected header, delimiter, quote, comment or infer", key))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func csvRune(scope *Scope, name string, obj Object) (rune, Object) {
+	s := obj.AsString()
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, scope.Interrupt(Raise("option '%s' must be a single character, got '%s'", name, s))
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+// Converts the field to a number or boolean if infer is true and it looks
+// like one.
+func csvValue(field string, infer bool) Object {
+	if !infer {
+		return NewString(field)
+	}
+
+	switch field {
+	case "true":
+		return True
+	case "false":
+		return False
+	}
+	if csvNumber.MatchString(field) {
+		if n, err := strconv.ParseFloat(field, 64); err == nil {
+			return NewNumber(n)
+		}
+	}
+	return NewString(field)
+}
+
+// Plain decimal numbers, leaving out the other forms ParseFloat accepts, like
+// 'NaN', 'Inf', '0x1p3' or '1_0'.
+var csvNumber = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+
+func csvField(obj Object) string {
+	switch obj := obj.(type) {
+	case *Number:
+		return strconv.FormatFloat(obj.Value, 'f', -1, 64)
+	case *Maybe:
+		if !obj.Ok {
+			return ""
+		}
+		return csvField(obj.Value)
+	}
+	return obj.AsString()
+}
+
+func valuesOfRecord(record Object) []Object {
+	switch record := record.(type) {
+	case *List:
+		return record.Elements
+	case *Tuple:
+		return record.Elements
+	}
+	return nil
+}
+
+// streamReader reads the elements of the stream as lines. It is only used by
+// the goroutine holding the interpreter lock.
+type streamReader struct {
+	scope  *Scope
+	stream *Stream
+	buffer []byte
+	err    Object
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		maybe := Stream_Next.Call(r.scope, r.stream)
+		if isRaise(maybe) {
+			r.err = maybe
+			return 0, errors.New("failed to read the stream")
+		}
+		if r.stream.Finished {
+			return 0, io.EOF
+		}
+		r.buffer = []byte(streamValue(r.stream, maybe.(*Maybe).Value).AsString() + "\n")
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+	return n, nil
+}
+
+// csvReader parses the records of a CSV, supporting any quote character,
+// unlike encoding/csv.
+type csvReader struct {
+	scope    *Scope
+	r        *bufio.Reader
+	opts     *csvReadOptions
+	line     int  // line of the last record read
+	next     int  // line of the next record
+	blocking bool // true if reading may block, like reading a file
+}
+
+func newCsvReader(scope *Scope, r io.Reader, opts *csvReadOptions, blocking bool) *csvReader {
+	return &csvReader{scope: scope, r: bufio.NewReader(r), opts: opts, next: 1, blocking: blocking}
+}
+
+// Reads a rune, releasing the interpreter lock if reading may block.
+func (c *csvReader) readRune() (rune, error) {
+	if !c.blocking || c.r.Buffered() > 0 {
+		r, _, err := c.r.ReadRune()
+		return r, err
+	}
+
+	var r rune
+	var err error
+	c.scope.Blocking(func() {
+		r, _, err = c.r.ReadRune()
+	})
+	return r, err
+}
+
+// Returns the fields of the next record, skipping empty and comment lines.
+func (c *csvReader) read() ([]string, error) {
+	for {
+		fields, err := c.readRecord()
+		if err != nil || fields != nil {
+			return fields, err
+		}
+	}
+}
+
+// Reads a line, returning nil fields for empty and comment lines.
+func (c *csvReader) readRecord() ([]string, error) {
+	c.line = c.next
+	r, err := c.readRune()
+	if err != nil {
+		return nil, err
+	}
+
+	if r == '\n' || r == '\r' {
+		c.next++
+		if r == '\r' {
+			c.skipLineFeed()
+		}
+		return nil, nil
+	}
+	if c.opts.comment != 0 && r == c.opts.comment {
+		for r != '\n' {
+			if r, err = c.readRune(); err != nil {
+				return nil, nil
+			}
+		}
+		c.next++
+		return nil, nil
+	}
+
+	var fields []string
+	field := &strings.Builder{}
+	quoted := false // inside a quoted field
+loop:
+	for {
+		switch {
+		case quoted && r == c.opts.quote:
+			r, err = c.readRune()
+			if err != nil {
+				quoted = false
+				break loop
+			}
+			if r == c.opts.quote {
+				field.WriteRune(r) // escaped quote
+				break
+			}
+			quoted = false
+			continue
+
+		case quoted:
+			if r == '\n' {
+				c.next++
+			}
+			field.WriteRune(r)
+
+		case r == c.opts.quote && c.opts.quote != 0 && field.Len() == 0:
+			quoted = true
+
+		case r == c.opts.delimiter:
+			fields = append(fields, field.String())
+			field.Reset()
+
+		case r == '\n' || r == '\r':
+			if r == '\r' {
+				c.skipLineFeed()
+			}
+			c.next++
+			return append(fields, field.String()), nil
+
+		default:
+			field.WriteRune(r)
+		}
+
+		if r, err = c.readRune(); err != nil {
+			break
+		}
+	}
+
+	if err != io.EOF {
+		return nil, err
+	}
+	if quoted {
+		return nil, errors.New("unterminated quoted field")
+	}
+	return append(fields, field.String()), nil
+}
+
+func (c *csvReader) skipLineFeed() {
+	if r, err := c.readRune(); err == nil && r != '\n' {
+		c.r.UnreadRune()
+	}
+}
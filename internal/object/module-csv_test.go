@@ -0,0 +1,53 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Csv_Read(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.csv")
+	os.WriteFile(path, []byte("name,age,ok\r\nann,30,true\n\n\"b, \"\"c\"\"\",4.5,false\n"), 0o644)
+
+	common.AssertCode(t, ` Csv.Read('`+path+`') | map (r: r['name']) | List`, `['ann', 'b, "c"']`)
+	common.AssertCode(t, ` Csv.Read('`+path+`') | map (r: r['age']) | sum`, `34.500000`)
+	common.AssertCode(t, ` Csv.Read('`+path+`') | map (r: r['ok']) | List`, `[true, false]`)
+	common.AssertCode(t, ` Csv.Read('`+path+`', {infer=false}) | map (r: r['age']) | List`, `['30', '4.5']`)
+	common.AssertCode(t, ` Csv.Read('`+path+`', {header=false}) | take 1 | List`, `[['name', 'age', 'ok']]`)
+	common.AssertCode(t, ` Csv.Read('`+path+`') | take 1 | count`, `1`)
+	common.AssertCodeError(t, ` Csv.Read('`+dir+`/missing.csv') | List`)
+
+	os.WriteFile(path, []byte("a\n-1.5e3\n1e500\nNaN\nInf\n-Infinity\n1_0\n0x10\n.5\n 1\n"), 0o644)
+	common.AssertCode(t, ` Csv.Read('`+path+`') | map (r: r['a']) | List`, `[-1500, '1e500', 'NaN', 'Inf', '-Infinity', '1_0', '0x10', '.5', ' 1']`)
+
+	os.WriteFile(path, []byte("a,b\n1,2\n3\n"), 0o644)
+	common.AssertCodeError(t, ` Csv.Read('`+path+`') | List`)
+	os.WriteFile(path, []byte("a\n\"1\n"), 0o644)
+	common.AssertCodeError(t, ` Csv.Read('`+path+`') | List`)
+}
+
+func TestModule_Csv_ReadStream(t *testing.T) {
+	common.AssertCode(t, ` ['# comment', "a;'b;c'", '1;2'] | Csv.Read {delimiter=';', quote="'", comment='#', header=false} | List`, `[['a', 'b;c'], [1, 2]]`)
+	common.AssertCode(t, ` ['"a', 'b"'] | Csv.Read {header=['x']} | map (r: r['x']) | List`, `['a\nb']`)
+	common.AssertCode(t, ` ['"a"'] | Csv.Read {quote='', header=false} | List`, `[['"a"']]`)
+	common.AssertCodeError(t, ` [] | Csv.Read {delimiter=';;'}`)
+	common.AssertCodeError(t, ` [] | Csv.Read {foo=1}`)
+}
+
+func TestModule_Csv_Write(t *testing.T) {
+	dir := t.TempDir()
+	common.AssertCode(t, ` [{b=1, a='x,y'}, {a=2.5}] | Csv.Write '`+dir+`/a.csv'`, `2`)
+	common.AssertCode(t, ` File.Read('`+dir+`/a.csv')`, "b,a\n1,\"x,y\"\n,2.5\n")
+	common.AssertCode(t, ` Csv.Read('`+dir+`/a.csv') | map (r: r['a']) | List`, `['x,y', 2.500000]`)
+
+	common.AssertCode(t, ` [{b=1, a=2}] | Csv.Write '`+dir+`/a.csv', {columns=['b', 'a'], delimiter=';'}; File.Read('`+dir+`/a.csv')`, "b;a\n1;2\n")
+	common.AssertCode(t, ` [{a=1}] | Csv.Write '`+dir+`/a.csv', {header=false}; File.Read('`+dir+`/a.csv')`, "1\n")
+	common.AssertCode(t, ` [[1, 'a'], (2, 'b')] | Csv.Write '`+dir+`/a.csv'; File.Read('`+dir+`/a.csv')`, "1,a\n2,b\n")
+	common.AssertCode(t, ` data P { name = ''; age = 0 }; [P { name='a' }] | Csv.Write '`+dir+`/a.csv'; File.Read('`+dir+`/a.csv')`, "age,name\n0,a\n")
+	common.AssertCodeError(t, ` [1] | Csv.Write '`+dir+`/a.csv'`)
+	common.AssertCodeError(t, ` [{a=1}] | Csv.Write '`+dir+`/missing/a.csv'`)
+}
@@ -0,0 +1,208 @@
+package object
+
+import (
+	"os"
+	"runtime"
+	"time"
+)
+
+var Module_Exec = NewModuleType("Exec")
+
+func init() {
+	Module_Exec.AddMethod(Module_Exec_Start)
+	Module_Exec.AddMethod(Module_Exec_Run)
+}
+
+// ----------------------------------------------------------------------------
+// Commands are given by their name and arguments, optionally followed by a
+// dict of options:
+//
+// - env: dict of environment variables, added to the ones of the script;
+// - cwd: working directory;
+// - timeout: duration after which the process is killed;
+// - stdin: string or stream written to the process, a stream one line per
+//   element.
+//
+// The processes run outside the interpreter lock, so several of them can run
+// at the same time, like inside `pmap`.
+// ----------------------------------------------------------------------------
+
+var Module_Exec_Start = F(
+	func(scope *Scope, args ...Object) Object {
+		spec, err := processSpecOf(scope, args)
+		if err != nil {
+			return err
+		}
+
+		p, err := StartProcess(scope, spec)
+		if err != nil {
+			return err
+		}
+		return p
+	},
+	"Start",
+	"Starts the command without waiting for it, returning its process. The output can be read while it runs.",
+	P("command", V.Type(StringId)),
+	P("args").AsSpread(),
+)
+
+var Module_Exec_Run = F(
+	func(scope *Scope, args ...Object) Object {
+		spec, err := processSpecOf(scope, args)
+		if err != nil {
+			return err
+		}
+		return runProcess(scope, spec)
+	},
+	"Run",
+	"Runs the command, waiting for it to finish, and returns its process. Raises if it exits with a non-zero code.",
+	P("command", V.Type(StringId)),
+	P("args").AsSpread(),
+)
+
+var Sh = F(
+	func(scope *Scope, args ...Object) Object {
+		shell := []Object{NewString("sh"), NewString("-c")}
+		if runtime.GOOS == "windows" {
+			shell = []Object{NewString("cmd"), NewString("/C")}
+		}
+
+		spec, err := processSpecOf(scope, append(shell, args...))
+		if err != nil {
+			return err
+		}
+		return runProcess(scope, spec)
+	},
+	`sh`,
+	`Runs the command line with the shell of the system, waiting for it to finish, and returns its process. Raises if it exits with a non-zero code.`,
+	P("command", V.Type(StringId)),
+	P("options"),
+)
+
+var Exec = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		spec, err := processSpecOf(scope, args[1:])
+		if err != nil {
+			return err
+		}
+		spec.stdin = stream
+
+		var p *Process
+		var lines *Stream
+		return NewInternalStream(func(s *Scope) Object {
+			if p == nil {
+				started, err := StartProcess(s, spec)
+				if err != nil {
+					return err
+				}
+				p, lines = started, started.stdout.stream(s)
+			}
+
+			maybe := Stream_Next.Call(s, lines)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if lines.Finished {
+				return p.Check(s)
+			}
+			return YieldWith(maybe.(*Maybe).Value)
+		}, scope).OnClose(func(s *Scope) Object {
+			if p != nil && !p.IsDone() {
+				p.Kill()
+			}
+			return stream.Close(s)
+		})
+	},
+	`exec`,
+	`Runs the command with the elements of the stream as its stdin, one per line, yielding the lines of its stdout. Raises if it exits with a non-zero code.`,
+	P("stream"),
+	P("command", V.Type(StringId)),
+	P("args").AsSpread(),
+)
+
+// processSpec is a command to be started, with its options.
+type processSpec struct {
+	name    string
+	args    []string
+	env     []string
+	dir     string
+	timeout time.Duration
+	stdin   Object // string or stream, nil if not given
+}
+
+// Returns the command of the arguments, where the last argument may be a dict
+// of options.
+func processSpecOf(scope *Scope, args []Object) (*processSpec, Object) {
+	spec := &processSpec{name: args[0].AsString()}
+
+	args = args[1:]
+	if n := len(args); n > 0 && args[n-1].TypeId() == DictId {
+		if err := spec.setOptions(scope, args[n-1].(*Dict)); err != nil {
+			return nil, err
+		}
+		args = args[:n-1]
+	}
+
+	for _, arg := range args {
+		spec.args = append(spec.args, arg.AsString())
+	}
+	return spec, nil
+}
+
+func (spec *processSpec) setOptions(scope *Scope, opts *Dict) Object {
+	for key, value := range opts.Elements {
+		switch key {
+		case "env":
+			env, ok := value.(*Dict)
+			if !ok {
+				return scope.Interrupt(Raise("option 'env' must be a dict, got '%s'", value.TypeId()))
+			}
+			spec.env = os.Environ()
+			for name, value := range env.Elements {
+				spec.env = append(spec.env, name+"="+value.AsString())
+			}
+
+		case "cwd":
+			spec.dir = value.AsString()
+
+		case "timeout":
+			d, err := durationOf(scope, value)
+			if err != nil {
+				return err
+			}
+			spec.timeout = d
+
+		case "stdin":
+			if value.TypeId() == StringId {
+				spec.stdin = value
+				break
+			}
+			s := StreamTypeObj.Convert(scope, value)
+			if isRaise(s) {
+				return s
+			}
+			spec.stdin = s
+
+		default:
+			return scope.Interrupt(Raise("unknown option '%s', expected env, cwd, timeout or stdin", key))
+		}
+	}
+	return nil
+}
+
+func runProcess(scope *Scope, spec *processSpec) Object {
+	p, err := StartProcess(scope, spec)
+	if err != nil {
+		return err
+	}
+	if err := p.Check(scope); err != nil {
+		return err
+	}
+	return p
+}
@@ -0,0 +1,67 @@
+//go:build unix
+
+package object_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Exec_Run(t *testing.T) {
+	common.AssertCode(t, ` Exec.Run('echo', 'a', 'b').Output()`, "a b\n")
+	common.AssertCode(t, ` Exec.Run('sh', '-c', 'echo a; echo b').Stdout() | List`, `['a', 'b']`)
+	common.AssertCode(t, ` Exec.Run('sh', '-c', 'echo a >&2').Stderr() | List`, `['a']`)
+	common.AssertCode(t, ` Exec.Run('cat', {stdin='a\nb'}).Stdout() | List`, `['a', 'b']`)
+	common.AssertCode(t, ` Exec.Run('cat', {stdin=[1, 2]}).Stdout() | List`, `['1', '2']`)
+	common.AssertCode(t, ` Exec.Run('pwd', {cwd='/'}).Output()`, "/\n")
+	common.AssertCode(t, ` Exec.Run('sh', '-c', 'echo $PIPE_X', {env={PIPE_X='x'}}).Output()`, "x\n")
+	common.AssertCodeError(t, ` Exec.Run('pwd', {foo=1})`)
+	common.AssertCodeError(t, ` Exec.Run('pipe-missing-command')`)
+}
+
+func TestModule_Exec_Errors(t *testing.T) {
+	common.AssertCodeError(t, ` Exec.Run('false')`)
+	common.AssertCode(t, ` Exec.Run('false')?.Ok()`, `false`)
+	common.AssertCode(t, ` sh('echo failed >&2; exit 3')?.Error()`, `command 'sh -c echo failed >&2; exit 3' exited with code 3: failed`)
+	common.AssertCode(t, ` Exec.Run('sleep', '5', {timeout=50})?.Error()`, `command 'sleep 5' failed: timed out after 50ms`)
+}
+
+func TestModule_Exec_Start(t *testing.T) {
+	common.AssertCode(t, ` p := Exec.Start('sh', '-c', 'exit 2'); p.Code(), p.Ok(), p.Done()`, `(2, false, true)`)
+	common.AssertCode(t, ` p := Exec.Start('sh', '-c', 'echo a; sleep 0.05; echo b'); p.Stdout() | List`, `['a', 'b']`)
+	common.AssertCode(t, ` p := Exec.Start('sleep', '5'); p.Kill(); p.Code()`, `-1`)
+	common.AssertCodeError(t, ` Exec.Start('false').Wait()`)
+
+	// The unread output is limited, blocking the process until it is read
+	common.AssertCode(t, ` p := Exec.Start('sh', '-c', 'head -c 1000000 /dev/zero; echo'); sleep(100); p.Done(), p.Output().Size()`, `(false, 1000001)`)
+	common.AssertCode(t, ` p := Exec.Start('yes'); xs := p.Stdout() | take 2 | List; p.Kill(); xs`, `['y', 'y']`)
+	common.AssertCode(t, ` p := Exec.Start('printf', 'a\\nb\\nc'); (p.Stdout() | first).Value(), (p.Stdout() | List)`, `('a', ['b', 'c'])`)
+
+	// Processes run outside the interpreter lock
+	start := time.Now()
+	common.AssertCode(t, ` [1, 2, 3, 4] | map (x: Exec.Start('sleep', '0.1')) | List | map (p: p.Code()) | sum`, `0`)
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("expected the processes to run at the same time, took %s", elapsed)
+	}
+}
+
+func TestFunction_Sh(t *testing.T) {
+	common.AssertCode(t, ` sh('echo a | tr a b').Output()`, "b\n")
+	common.AssertCode(t, ` sh('echo $0', {env={}}).Ok()`, `true`)
+	common.AssertCodeError(t, ` sh('exit 1')`)
+}
+
+func TestFunction_Exec(t *testing.T) {
+	common.AssertCode(t, ` ['b', 'a', 'b'] | exec 'sort', '-u' | List`, `['a', 'b']`)
+	common.AssertCode(t, ` range(3) | exec 'cat' | List`, `['0', '1', '2']`)
+	common.AssertCode(t, ` range(100000) | exec 'cat' | take 2 | List`, `['0', '1']`)
+	common.AssertCode(t, ` range(100000) | exec 'head', '-n', '2' | List`, `['0', '1']`)
+	common.AssertCode(t, ` [] | exec 'echo', 'a' | List`, `['a']`)
+	common.AssertCodeError(t, ` [1] | exec 'sh', '-c', 'exit 1' | List`)
+	common.AssertCodeError(t, ` [1, 2] | map (x: raise 'x') | exec 'cat' | List`)
+	common.AssertCodeError(t, ` [1] | map (x: if sleep(300) == false { raise 'bad' } else { x }) | exec 'sleep', '0.1' | List`)
+	common.AssertCode(t, ` p := Exec.Start('sleep', '0.1', {stdin=[1] | map x: (if sleep(300) == false { raise 'bad' } else { x })}); p.Ok()`, `false`)
+	common.AssertCode(t, ` Exec.Start('head', '-n', '1', {stdin=range(100000)}).Ok()`, `true`)
+}
@@ -0,0 +1,573 @@
+package object
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var Module_File = NewModuleType("File")
+
+func init() {
+	Module_File.AddMethod(Module_File_Read)
+	Module_File.AddMethod(Module_File_Write)
+	Module_File.AddMethod(Module_File_Append)
+	Module_File.AddMethod(Module_File_Lines)
+	Module_File.AddMethod(Module_File_Glob)
+	Module_File.AddMethod(Module_File_Walk)
+	Module_File.AddMethod(Module_File_Stat)
+	Module_File.AddMethod(Module_File_Exists)
+	Module_File.AddMethod(Module_File_IsDir)
+	Module_File.AddMethod(Module_File_Copy)
+	Module_File.AddMethod(Module_File_Move)
+	Module_File.AddMethod(Module_File_Remove)
+	Module_File.AddMethod(Module_File_MkdirAll)
+	Module_File.AddMethod(Module_File_TempFile)
+	Module_File.AddMethod(Module_File_TempDir)
+}
+
+// ----------------------------------------------------------------------------
+// The file functions release the interpreter lock while waiting for the disk.
+// Entries, returned by `Stat` and `Walk`, are dicts with the keys `path`,
+// `name`, `size`, `mode`, `mtime` (in seconds) and `isDir`.
+// ----------------------------------------------------------------------------
+
+var Module_File_Read = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var data []byte
+		var err error
+		scope.Blocking(func() {
+			data, err = os.ReadFile(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewString(string(data))
+	},
+	"Read",
+	"Returns the content of the file as a string.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_Write = F(
+	func(scope *Scope, args ...Object) Object {
+		return writeFile(scope, args[0].AsString(), args[1].AsString(), os.O_TRUNC)
+	},
+	"Write",
+	"Writes the content to the file, replacing it if it exists. Returns the path.",
+	P("path", V.Type(StringId)),
+	P("content"),
+)
+
+var Module_File_Append = F(
+	func(scope *Scope, args ...Object) Object {
+		return writeFile(scope, args[0].AsString(), args[1].AsString(), os.O_APPEND)
+	},
+	"Append",
+	"Appends the content to the file, creating it if it does not exist. Returns the path.",
+	P("path", V.Type(StringId)),
+	P("content"),
+)
+
+var Module_File_Lines = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var file *os.File
+		var reader *bufio.Reader
+		return NewInternalStream(func(s *Scope) Object {
+			var line string
+			var err error
+			s.Blocking(func() {
+				if file == nil {
+					file, err = os.Open(path)
+					if err != nil {
+						return
+					}
+					reader = bufio.NewReader(file)
+				}
+				line, err = reader.ReadString('\n')
+			})
+
+			switch {
+			case err == io.EOF && line == "":
+				return nil
+			case err != nil && err != io.EOF:
+				return fileError(s, err)
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			return YieldWith(NewString(line))
+		}, scope).OnClose(func(s *Scope) Object {
+			if file != nil {
+				file.Close()
+			}
+			return nil
+		})
+	},
+	"Lines",
+	"Returns a stream of the lines of the file, without the line breaks. The file is read as the stream is consumed, and closed when it finishes.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_Glob = F(
+	func(scope *Scope, args ...Object) Object {
+		pattern := args[0].AsString()
+
+		var paths []string
+		var err error
+		scope.Blocking(func() {
+			paths, err = glob(pattern)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+
+		list := make([]Object, len(paths))
+		for i, path := range paths {
+			list[i] = NewString(path)
+		}
+		return NewList(list...)
+	},
+	"Glob",
+	"Returns a sorted list of the paths matching the pattern. Besides the `*`, `?` and `[...]` wildcards, `**` matches any number of directories, like in `'photos/**/*.jpg'`.",
+	P("pattern", V.Type(StringId)),
+)
+
+var Module_File_Walk = F(
+	func(scope *Scope, args ...Object) Object {
+		root := args[0].AsString()
+
+		// Paths to be yielded, the next one at the end
+		var pending []string
+		started := false
+		return NewInternalStream(func(s *Scope) Object {
+			var info fs.FileInfo
+			var path string
+			var err error
+			s.Blocking(func() {
+				if !started {
+					started = true
+					pending, err = walkChildren(root)
+					if err != nil {
+						return
+					}
+				}
+				if len(pending) == 0 {
+					return
+				}
+
+				path = pending[len(pending)-1]
+				pending = pending[:len(pending)-1]
+				info, err = os.Lstat(path)
+				if err != nil || !info.IsDir() {
+					return
+				}
+
+				// Directories that cannot be read are yielded, but skipped
+				if children, readErr := walkChildren(path); readErr == nil {
+					pending = append(pending, children...)
+				}
+			})
+
+			if err != nil {
+				return fileError(s, err)
+			}
+			if info == nil {
+				return nil
+			}
+			return YieldWith(fileEntry(path, info))
+		}, scope)
+	},
+	"Walk",
+	"Returns a stream of the entries inside the directory, recursively, in lexical order. Each directory comes before its content. Symbolic links are not followed.",
+	P("root", V.Type(StringId)),
+)
+
+var Module_File_Stat = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var info fs.FileInfo
+		var err error
+		scope.Blocking(func() {
+			info, err = os.Stat(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return fileEntry(path, info)
+	},
+	"Stat",
+	"Returns the entry of the path.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_Exists = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var err error
+		scope.Blocking(func() {
+			_, err = os.Stat(path)
+		})
+		return NewBoolean(err == nil)
+	},
+	"Exists",
+	"Returns true if the path exists.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_IsDir = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var info fs.FileInfo
+		var err error
+		scope.Blocking(func() {
+			info, err = os.Stat(path)
+		})
+		return NewBoolean(err == nil && info.IsDir())
+	},
+	"IsDir",
+	"Returns true if the path exists and is a directory.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_Copy = F(
+	func(scope *Scope, args ...Object) Object {
+		src, dst := args[0].AsString(), args[1].AsString()
+
+		var err error
+		scope.Blocking(func() {
+			err = copyPath(src, dst)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return args[1]
+	},
+	"Copy",
+	"Copies the file to the destination, replacing it if it exists. Directories are copied with their content. Returns the destination.",
+	P("src", V.Type(StringId)),
+	P("dst", V.Type(StringId)),
+)
+
+var Module_File_Move = F(
+	func(scope *Scope, args ...Object) Object {
+		src, dst := args[0].AsString(), args[1].AsString()
+
+		var err error
+		scope.Blocking(func() {
+			err = os.Rename(src, dst)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return args[1]
+	},
+	"Move",
+	"Moves or renames the file or directory. Returns the destination.",
+	P("src", V.Type(StringId)),
+	P("dst", V.Type(StringId)),
+)
+
+var Module_File_Remove = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+		remove := os.Remove
+		if len(args) > 1 && args[1].AsBool() {
+			remove = os.RemoveAll
+		}
+
+		var err error
+		scope.Blocking(func() {
+			err = remove(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return args[0]
+	},
+	"Remove",
+	"Removes the file or the empty directory. If recursive is true, removes the directory with its content, not raising if the path does not exist. Returns the path.",
+	P("path", V.Type(StringId)),
+	P("recursive"),
+)
+
+var Module_File_MkdirAll = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var err error
+		scope.Blocking(func() {
+			err = os.MkdirAll(path, 0o755)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return args[0]
+	},
+	"MkdirAll",
+	"Creates the directory with all missing parents. Returns the path.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_File_TempFile = F(
+	func(scope *Scope, args ...Object) Object {
+		pattern := ""
+		if len(args) > 0 {
+			pattern = args[0].AsString()
+		}
+
+		var file *os.File
+		var err error
+		scope.Blocking(func() {
+			file, err = os.CreateTemp("", pattern)
+			if err == nil {
+				err = file.Close()
+			}
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewString(file.Name())
+	},
+	"TempFile",
+	"Creates an empty file in the temporary directory, returning its path. A `*` in the pattern is replaced by a random string.",
+	P("pattern"),
+)
+
+var Module_File_TempDir = F(
+	func(scope *Scope, args ...Object) Object {
+		pattern := ""
+		if len(args) > 0 {
+			pattern = args[0].AsString()
+		}
+
+		var path string
+		var err error
+		scope.Blocking(func() {
+			path, err = os.MkdirTemp("", pattern)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewString(path)
+	},
+	"TempDir",
+	"Creates a directory in the temporary directory, returning its path. A `*` in the pattern is replaced by a random string.",
+	P("pattern"),
+)
+
+var WriteLines = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		path := args[1].AsString()
+
+		var file *os.File
+		var err error
+		scope.Blocking(func() {
+			file, err = os.Create(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+		writer := bufio.NewWriter(file)
+
+		count := 0
+		ret := stream.Resolve(func(value Object) Object {
+			line := streamValue(stream, value).AsString() + "\n"
+			scope.Blocking(func() {
+				_, err = writer.WriteString(line)
+			})
+			if err != nil {
+				return stream.CloseWith(scope, fileError(scope, err))
+			}
+			count++
+			return nil
+		})
+
+		scope.Blocking(func() {
+			err = errors.Join(writer.Flush(), file.Close())
+		})
+		if isRaise(ret) {
+			return ret
+		}
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewNumber(float64(count))
+	},
+	`writeLines`,
+	`Writes each element of the stream as a line of the file, replacing it if it exists. Returns the number of lines written.`,
+	P("stream"),
+	P("path", V.Type(StringId)),
+)
+
+func fileError(scope *Scope, err error) Object {
+	return scope.Interrupt(Raise("%s", err.Error()))
+}
+
+func writeFile(scope *Scope, path, content string, flag int) Object {
+	var err error
+	scope.Blocking(func() {
+		var file *os.File
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|flag, 0o644)
+		if err != nil {
+			return
+		}
+		_, err = file.WriteString(content)
+		err = errors.Join(err, file.Close())
+	})
+	if err != nil {
+		return fileError(scope, err)
+	}
+	return NewString(path)
+}
+
+func fileEntry(path string, info fs.FileInfo) *Dict {
+	return NewDict(map[string]Object{
+		"path":  NewString(path),
+		"name":  NewString(info.Name()),
+		"size":  NewNumber(float64(info.Size())),
+		"mode":  NewString(info.Mode().String()),
+		"mtime": NewNumber(float64(info.ModTime().UnixMilli()) / 1000),
+		"isDir": NewBoolean(info.IsDir()),
+	})
+}
+
+// Returns the paths of the directory content in reverse lexical order, so
+// they can be popped in order.
+func walkChildren(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[len(entries)-1-i] = filepath.Join(dir, entry.Name())
+	}
+	return paths, nil
+}
+
+// Expands the pattern, supporting `**` for any number of directories.
+func glob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	// Walks from the longest directory without wildcards
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	base := 0
+	for base < len(segments)-1 && !strings.ContainsAny(segments[base], "*?[\\") {
+		base++
+	}
+	root := strings.Join(segments[:base], "/")
+	if root == "" && base > 0 {
+		root = "/"
+	}
+
+	var matches []string
+	err := filepath.WalkDir(cmp.Or(root, "."), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(cmp.Or(root, "."), path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		if globMatch(segments[base:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	slices.Sort(matches)
+	return matches, err
+}
+
+// Matches the path segments against the pattern segments, where `**` matches
+// zero or more segments.
+func globMatch(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if globMatch(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+	ok, err := filepath.Match(pattern[0], path[0])
+	return err == nil && ok && globMatch(pattern[1:], path[1:])
+}
+
+// Copies the file or the directory with its content, keeping the modes.
+func copyPath(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+
+			target := filepath.Join(dst, rel)
+			if d.IsDir() {
+				return os.MkdirAll(target, 0o755)
+			}
+			return copyFile(path, target)
+		})
+	}
+
+	return copyFile(src, dst)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	return errors.Join(err, out.Close())
+}
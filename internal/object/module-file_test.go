@@ -0,0 +1,71 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+// Replaces `$dir` in the code by the directory.
+func inDir(dir, code string) string {
+	return strings.ReplaceAll(code, "$dir", dir)
+}
+
+func TestModule_File_ReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	common.AssertCode(t, inDir(dir, ` File.Write('$dir/a.txt', 'hello'); File.Read('$dir/a.txt')`), `hello`)
+	common.AssertCode(t, inDir(dir, ` File.Append('$dir/a.txt', ' world'); File.Read('$dir/a.txt')`), `hello world`)
+	common.AssertCode(t, inDir(dir, ` File.Append('$dir/b.txt', 1); File.Read('$dir/b.txt')`), `1`)
+	common.AssertCode(t, inDir(dir, ` File.Exists('$dir/a.txt'), File.Exists('$dir/c.txt')`), `(true, false)`)
+	common.AssertCode(t, inDir(dir, ` File.IsDir('$dir'), File.IsDir('$dir/a.txt')`), `(true, false)`)
+	common.AssertCodeError(t, inDir(dir, ` File.Read('$dir/missing.txt')`))
+}
+
+func TestModule_File_Lines(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\r\ntwo\n\nthree"), 0o644)
+
+	common.AssertCode(t, inDir(dir, ` File.Lines('$dir/a.txt') | List`), `['one', 'two', '', 'three']`)
+	common.AssertCode(t, inDir(dir, ` File.Lines('$dir/a.txt') | take 1 | List`), `['one']`)
+	common.AssertCode(t, inDir(dir, ` File.Lines('$dir/a.txt') | count`), `4`)
+	common.AssertCodeError(t, inDir(dir, ` File.Lines('$dir/missing.txt') | List`))
+}
+
+func TestModule_File_WriteLines(t *testing.T) {
+	dir := t.TempDir()
+	common.AssertCode(t, inDir(dir, ` range(3) | map (x: x*2) | writeLines '$dir/a.txt'`), `3`)
+	common.AssertCode(t, inDir(dir, ` File.Read('$dir/a.txt')`), "0\n2\n4\n")
+	common.AssertCode(t, inDir(dir, ` ['a', 'b'] | writeLines '$dir/a.txt'; File.Lines('$dir/a.txt') | List`), `['a', 'b']`)
+	common.AssertCodeError(t, inDir(dir, ` [1] | writeLines '$dir/missing/a.txt'`))
+}
+
+func TestModule_File_GlobWalk(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"a.jpg", "b.txt", "x/c.jpg", "x/y/d.jpg", "x/y/e.txt"} {
+		os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0o755)
+		os.WriteFile(filepath.Join(dir, path), []byte(path), 0o644)
+	}
+
+	common.AssertCode(t, inDir(dir, ` File.Glob('$dir/*.jpg') | map (x: x.Replace('$dir/', '')) | List`), `['a.jpg']`)
+	common.AssertCode(t, inDir(dir, ` File.Glob('$dir/**/*.jpg') | map (x: x.Replace('$dir/', '')) | List`), `['a.jpg', 'x/c.jpg', 'x/y/d.jpg']`)
+	common.AssertCode(t, inDir(dir, ` File.Glob('$dir/x/**') | map (x: x.Replace('$dir/', '')) | List`), `['x/c.jpg', 'x/y', 'x/y/d.jpg', 'x/y/e.txt']`)
+	common.AssertCode(t, inDir(dir, ` File.Walk('$dir') | map (e: e['path'].Replace('$dir/', '')) | List`), `['a.jpg', 'b.txt', 'x', 'x/c.jpg', 'x/y', 'x/y/d.jpg', 'x/y/e.txt']`)
+	common.AssertCode(t, inDir(dir, ` File.Walk('$dir') | filter (e: not e['isDir']) | map (e: e['size']) | sum`), `35`)
+	common.AssertCode(t, inDir(dir, ` e := File.Stat('$dir/x/c.jpg'); e['name'], e['size'], e['mode'], e['isDir']`), `('c.jpg', 7, '-rw-r--r--', false)`)
+	common.AssertCodeError(t, inDir(dir, ` File.Walk('$dir/missing') | List`))
+}
+
+func TestModule_File_Housekeeping(t *testing.T) {
+	dir := t.TempDir()
+	common.AssertCode(t, inDir(dir, ` File.MkdirAll('$dir/a/b'); File.Write('$dir/a/b/c.txt', 'c'); File.Copy('$dir/a', '$dir/d'); File.Read('$dir/d/b/c.txt')`), `c`)
+	common.AssertCode(t, inDir(dir, ` File.Move('$dir/d/b/c.txt', '$dir/e.txt'); File.Exists('$dir/d/b/c.txt'), File.Read('$dir/e.txt')`), `(false, 'c')`)
+	common.AssertCode(t, inDir(dir, ` File.Remove('$dir/e.txt'); File.Exists('$dir/e.txt')`), `false`)
+	common.AssertCodeError(t, inDir(dir, ` File.Remove('$dir/a')`))
+	common.AssertCode(t, inDir(dir, ` File.Remove('$dir/a', true); File.Exists('$dir/a')`), `false`)
+
+	common.AssertCode(t, ` p := File.TempFile('pipe-*.txt'); a, b := File.Exists(p), p.EndsWith('.txt'); File.Remove(p); a, b`, `(true, true)`)
+	common.AssertCode(t, ` p := File.TempDir(); r := File.IsDir(p); File.Remove(p); r`, `true`)
+}
@@ -0,0 +1,387 @@
+package object
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var Module_Json = NewModuleType("Json")
+
+func init() {
+	Module_Json.SetProperty("Null", Module_Json_Null)
+
+	Module_Json.AddMethod(Module_Json_Parse)
+	Module_Json.AddMethod(Module_Json_Stringify)
+	Module_Json.AddMethod(Module_Json_Lines)
+	Module_Json.AddMethod(Module_Json_WriteLines)
+	Module_Json.AddMethod(Module_Json_Elements)
+}
+
+// ----------------------------------------------------------------------------
+// JSON objects become dicts, arrays become lists and null becomes `Json.Null`,
+// an empty Maybe. When stringifying, data instances become objects with their
+// attributes, tuples and streams become arrays and Maybes become their value,
+// or null if empty.
+// ----------------------------------------------------------------------------
+
+var Module_Json_Null = NewMaybe(NewErrorFromString("null"))
+
+var Module_Json_Parse = F(
+	func(scope *Scope, args ...Object) Object {
+		value, err := parseJson(args[0].AsString())
+		if err != nil {
+			return scope.Interrupt(Raise("invalid json: %s", err.Error()))
+		}
+		return value
+	},
+	"Parse",
+	"Parses the JSON text.",
+	P("text", V.Type(StringId)),
+)
+
+var Module_Json_Stringify = F(
+	func(scope *Scope, args ...Object) Object {
+		indent, sortKeys := "", false
+		if len(args) > 1 {
+			opts, ok := args[1].(*Dict)
+			if !ok {
+				return scope.Interrupt(Raise("expected a dict of options, got '%s'", args[1].TypeId()))
+			}
+
+			for key, value := range opts.Elements {
+				switch key {
+				case "indent":
+					if num, ok := value.(*Number); ok {
+						indent = strings.Repeat(" ", int(num.Value))
+					} else {
+						indent = value.AsString()
+					}
+				case "sortKeys":
+					sortKeys = value.AsBool()
+				default:
+					return scope.Interrupt(Raise("unknown option '%s', expected indent or sortKeys", key))
+				}
+			}
+		}
+
+		text, err := stringifyJson(scope, args[0], indent, sortKeys)
+		if err != nil {
+			return err
+		}
+		return NewString(text)
+	},
+	"Stringify",
+	"Returns the value as JSON text. The options are `indent`, a number of spaces or a string, and `sortKeys`, which sorts the keys of the objects.",
+	P("value"),
+	P("options"),
+)
+
+var Module_Json_Lines = F(
+	func(scope *Scope, args ...Object) Object {
+		lines := Module_File_Lines.Call(scope, args[0])
+		if isRaise(lines) {
+			return lines
+		}
+		stream := lines.(*Stream)
+
+		n := 0
+		return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			for {
+				maybe := Stream_Next.Call(s, stream)
+				if isRaise(maybe) {
+					return maybe
+				}
+				if stream.Finished {
+					return nil
+				}
+
+				n++
+				line := maybe.(*Maybe).Value.AsString()
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+
+				value, err := parseJson(line)
+				if err != nil {
+					return s.Interrupt(Raise("invalid json at line %d: %s", n, err.Error()))
+				}
+				return YieldWith(value)
+			}
+		}, scope))
+	},
+	"Lines",
+	"Returns a stream of the values of a newline-delimited JSON file, one per line. Blank lines are skipped.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Json_WriteLines = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		lines := passThrough(stream, NewInternalStream(func(s *Scope) Object {
+			maybe := Stream_Next.Call(s, stream)
+			if isRaise(maybe) {
+				return maybe
+			}
+			if stream.Finished {
+				return nil
+			}
+
+			text, err := stringifyJson(s, streamValue(stream, maybe.(*Maybe).Value), "", false)
+			if err != nil {
+				return err
+			}
+			return YieldWith(NewString(text))
+		}, scope))
+		lines.Indexed = false
+		return WriteLines.Call(scope, lines, args[1])
+	},
+	"WriteLines",
+	"Writes each element of the stream as a line of JSON, replacing the file if it exists. Returns the number of lines written.",
+	P("stream"),
+	P("path", V.Type(StringId)),
+)
+
+var Module_Json_Elements = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var file *os.File
+		var decoder *json.Decoder
+		return NewInternalStream(func(s *Scope) Object {
+			var value Object
+			var err error
+			s.Blocking(func() {
+				if file == nil {
+					if file, err = os.Open(path); err != nil {
+						return
+					}
+					decoder = json.NewDecoder(bufio.NewReader(file))
+					if err = expectDelim(decoder, '['); err != nil {
+						return
+					}
+				}
+
+				if !decoder.More() {
+					err = expectDelim(decoder, ']')
+					if err == nil {
+						err = io.EOF
+					}
+					return
+				}
+				value, err = decodeJson(decoder)
+			})
+
+			switch {
+			case err == io.EOF:
+				return nil
+			case err != nil:
+				return s.Interrupt(Raise("invalid json in '%s': %s", path, err.Error()))
+			}
+			return YieldWith(value)
+		}, scope).OnClose(func(s *Scope) Object {
+			if file != nil {
+				file.Close()
+			}
+			return nil
+		})
+	},
+	"Elements",
+	"Returns a stream of the elements of the JSON array in the file, decoding one element at a time, so huge files can be read with little memory.",
+	P("path", V.Type(StringId)),
+)
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return errors.New("expected '" + delim.String() + "'")
+	}
+	return nil
+}
+
+// Decodes the text, which must hold a single value.
+func parseJson(text string) (Object, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	value, err := decodeJson(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected content after the value")
+	}
+	return value, nil
+}
+
+// Decodes the next value of the decoder. The values are read token by token,
+// so the objects are not built twice.
+func decodeJson(decoder *json.Decoder) (Object, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case nil:
+		return Module_Json_Null, nil
+	case bool:
+		return NewBoolean(token), nil
+	case float64:
+		return NewNumber(token), nil
+	case string:
+		return NewString(token), nil
+
+	case json.Delim:
+		if token == '[' {
+			list := []Object{}
+			for decoder.More() {
+				value, err := decodeJson(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			_, err := decoder.Token()
+			return NewList(list...), err
+		}
+
+		dict := NewDict(map[string]Object{})
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJson(decoder)
+			if err != nil {
+				return nil, err
+			}
+			dict.Set(key.(string), value)
+		}
+		_, err := decoder.Token()
+		return dict, err
+	}
+
+	return nil, errors.New("unexpected token")
+}
+
+func stringifyJson(scope *Scope, obj Object, indent string, sortKeys bool) (string, Object) {
+	buf := &bytes.Buffer{}
+	if err := encodeJson(scope, buf, obj, sortKeys); err != nil {
+		return "", err
+	}
+	if indent == "" {
+		return buf.String(), nil
+	}
+
+	indented := &bytes.Buffer{}
+	json.Indent(indented, buf.Bytes(), "", indent)
+	return indented.String(), nil
+}
+
+func encodeJson(scope *Scope, buf *bytes.Buffer, obj Object, sortKeys bool) Object {
+	switch obj := obj.(type) {
+	case *Number:
+		if math.IsNaN(obj.Value) || math.IsInf(obj.Value, 0) {
+			return scope.Interrupt(Raise("cannot convert '%s' to json", obj.AsString()))
+		}
+		buf.WriteString(strconv.FormatFloat(obj.Value, 'f', -1, 64))
+
+	case *Boolean:
+		buf.WriteString(strconv.FormatBool(obj.Value))
+
+	case *String:
+		encodeJsonString(buf, obj.Value)
+
+	case *Time:
+		encodeJsonString(buf, obj.AsString())
+
+	case *Maybe:
+		if !obj.Ok {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeJson(scope, buf, obj.Value, sortKeys)
+
+	case *List:
+		return encodeJsonArray(scope, buf, obj.Elements, sortKeys)
+
+	case *Tuple:
+		return encodeJsonArray(scope, buf, obj.Elements, sortKeys)
+
+	case *Stream:
+		list := ListTypeObj.Convert(scope, obj)
+		if isRaise(list) {
+			return list
+		}
+		return encodeJsonArray(scope, buf, list.(*List).Elements, sortKeys)
+
+	case *Dict:
+		return encodeJsonObject(scope, buf, obj, sortKeys)
+
+	case *Data:
+		return encodeJsonObject(scope, buf, recordToDict(scope, obj).(*Dict), sortKeys)
+
+	default:
+		return scope.Interrupt(Raise("cannot convert '%s' to json", obj.TypeId()))
+	}
+	return nil
+}
+
+func encodeJsonArray(scope *Scope, buf *bytes.Buffer, elements []Object, sortKeys bool) Object {
+	buf.WriteByte('[')
+	for i, e := range elements {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encodeJson(scope, buf, e, sortKeys); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func encodeJsonObject(scope *Scope, buf *bytes.Buffer, dict *Dict, sortKeys bool) Object {
+	keys := dict.Keys()
+	if sortKeys {
+		slices.Sort(keys)
+	}
+
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodeJsonString(buf, dict.KeyOf(key).AsString())
+		buf.WriteByte(':')
+		if err := encodeJson(scope, buf, dict.Elements[key], sortKeys); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func encodeJsonString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encode adds a line break
+}
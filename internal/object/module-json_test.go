@@ -0,0 +1,67 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Json_Parse(t *testing.T) {
+	common.AssertCode(t, ` Json.Parse('[1, "a", true, false]')`, `[1, 'a', true, false]`)
+	common.AssertCode(t, ` Json.Parse('{"a": {"b": [1, 2]}}')['a']['b']`, `[1, 2]`)
+	common.AssertCode(t, ` Json.Parse('"\\u00e9"')`, `é`)
+	common.AssertCode(t, ` Json.Parse('[null]')[0].Ok()`, `false`)
+	common.AssertCode(t, ` Json.Parse(' 3 ')`, `3`)
+	common.AssertCodeError(t, ` Json.Parse('[1,')`)
+	common.AssertCodeError(t, ` Json.Parse('[1] 2')`)
+	common.AssertCodeError(t, ` Json.Parse('[1]]')`)
+	common.AssertCodeError(t, ` Json.Parse('{"a":1}}')`)
+	common.AssertCodeError(t, ` Json.Parse('')`)
+}
+
+func TestModule_Json_Stringify(t *testing.T) {
+	common.AssertCode(t, ` Json.Stringify([1, 2.5, 'a"<b>', true, Json.Null])`, `[1,2.5,"a\"<b>",true,null]`)
+	common.AssertCode(t, ` Json.Stringify({b=1, a=[Maybe(2)]})`, `{"b":1,"a":[2]}`)
+	common.AssertCode(t, ` Json.Stringify({b=1, a=2}, {sortKeys=true})`, `{"a":2,"b":1}`)
+	common.AssertCode(t, ` Json.Stringify({a=[1]}, {indent=2})`, "{\n  \"a\": [\n    1\n  ]\n}")
+	common.AssertCode(t, ` Json.Stringify([1], {indent='\t'})`, "[\n\t1\n]")
+	common.AssertCode(t, ` Json.Stringify(((1, 2), range(2)))`, `[[1,2],[0,1]]`)
+	common.AssertCode(t, ` data P { name = ''; age = 0 }; Json.Stringify(P { name='a' })`, `{"age":0,"name":"a"}`)
+	common.AssertCode(t, ` v := Json.Parse('{"a":[1,{"b":null}]}'); Json.Stringify(v)`, `{"a":[1,{"b":null}]}`)
+	common.AssertCodeError(t, ` Json.Stringify(fn(){})`)
+	common.AssertCodeError(t, ` Json.Stringify(1, {foo=1})`)
+}
+
+func TestModule_Json_Lines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.ndjson")
+	os.WriteFile(path, []byte("{\"a\": 1}\n\n{\"a\": 2}\n"), 0o644)
+
+	common.AssertCode(t, ` Json.Lines('`+path+`') | map (x: x['a']) | List`, `[1, 2]`)
+	common.AssertCode(t, ` [{a=1}, [2]] | Json.WriteLines '`+dir+`/b.ndjson'`, `2`)
+	common.AssertCode(t, ` File.Read('`+dir+`/b.ndjson')`, "{\"a\":1}\n[2]\n")
+
+	os.WriteFile(path, []byte("{\"a\": 1}\n{\"a\": 2}}\n"), 0o644)
+	common.AssertCodeError(t, ` Json.Lines('`+path+`') | List`)
+
+	os.WriteFile(path, []byte("{\"a\": 1}\n{\"a\n"), 0o644)
+	common.AssertCode(t, ` Json.Lines('`+path+`') | take 1 | List | count`, `1`)
+	common.AssertCodeError(t, ` Json.Lines('`+path+`') | List`)
+}
+
+func TestModule_Json_Elements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.json")
+	os.WriteFile(path, []byte(` [ {"a": 1}, [2], 3 ] `), 0o644)
+	common.AssertCode(t, ` Json.Elements('`+path+`') | map (x: Json.Stringify(x)) | List`, `['{"a":1}', '[2]', '3']`)
+	common.AssertCode(t, ` Json.Elements('`+path+`') | take 1 | count`, `1`)
+
+	os.WriteFile(path, []byte(`[]`), 0o644)
+	common.AssertCode(t, ` Json.Elements('`+path+`') | count`, `0`)
+
+	os.WriteFile(path, []byte(`{"a": 1}`), 0o644)
+	common.AssertCodeError(t, ` Json.Elements('`+path+`') | List`)
+	os.WriteFile(path, []byte(`[1, 2`), 0o644)
+	common.AssertCodeError(t, ` Json.Elements('`+path+`') | List`)
+}
@@ -0,0 +1,9 @@
+//go:build !js
+
+package object
+
+import "syscall"
+
+func init() {
+	osSignals["SIGHUP"] = syscall.SIGHUP
+}
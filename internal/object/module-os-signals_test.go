@@ -0,0 +1,38 @@
+//go:build unix
+
+package object_test
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Os_Signals(t *testing.T) {
+	// Keeps the test from being killed by a signal sent before the stream
+	// listens to it
+	ignore := make(chan os.Signal, 1)
+	signal.Notify(ignore, syscall.SIGHUP)
+	defer signal.Stop(ignore)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				syscall.Kill(os.Getpid(), syscall.SIGHUP)
+			}
+		}
+	}()
+
+	common.AssertCode(t, ` Os.Signals('hup') | take 2 | List`, `['SIGHUP', 'SIGHUP']`)
+	common.AssertCode(t, ` s := Os.Signals('hup'); sleep(500); s | count`, `16`)
+	common.AssertCodeError(t, ` Os.Signals('SIGFOO')`)
+}
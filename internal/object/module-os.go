@@ -0,0 +1,301 @@
+package object
+
+import (
+	"bufio"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+var Module_Os = NewModuleType("Os")
+
+func init() {
+	Module_Os.AddMethod(Module_Os_Args)
+	Module_Os.AddMethod(Module_Os_Env)
+	Module_Os.AddMethod(Module_Os_SetEnv)
+	Module_Os.AddMethod(Module_Os_UnsetEnv)
+	Module_Os.AddMethod(Module_Os_Environ)
+	Module_Os.AddMethod(Module_Os_LoadEnv)
+	Module_Os.AddMethod(Module_Os_Exit)
+	Module_Os.AddMethod(Module_Os_Cwd)
+	Module_Os.AddMethod(Module_Os_Chdir)
+	Module_Os.AddMethod(Module_Os_Hostname)
+	Module_Os.AddMethod(Module_Os_Pid)
+	Module_Os.AddMethod(Module_Os_Signals)
+}
+
+// The signals accepted by `Os.Signals`, by name. SIGHUP is added on the
+// platforms supporting it.
+var osSignals = map[string]os.Signal{
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+var Module_Os_Args = F(
+	func(scope *Scope, args ...Object) Object {
+		list := make([]Object, len(scope.Args()))
+		for i, arg := range scope.Args() {
+			list[i] = NewString(arg)
+		}
+		return NewList(list...)
+	},
+	"Args",
+	"Returns the list of arguments given to the script, as in `pipe run script.pipe a b c`, without the script path.",
+)
+
+var Module_Os_Env = F(
+	func(scope *Scope, args ...Object) Object {
+		name := args[0].AsString()
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return NewMaybe(NewErrorFromString("environment variable '" + name + "' is not set"))
+		}
+		return NewMaybe(NewString(value))
+	},
+	"Env",
+	"Returns the value of the environment variable as a Maybe, holding an error if the variable is not set.",
+	P("name", V.Type(StringId)),
+)
+
+var Module_Os_SetEnv = F(
+	func(scope *Scope, args ...Object) Object {
+		if err := os.Setenv(args[0].AsString(), args[1].AsString()); err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return args[1]
+	},
+	"SetEnv",
+	"Sets the environment variable for the script and the processes it starts. Returns the value.",
+	P("name", V.Type(StringId)),
+	P("value"),
+)
+
+var Module_Os_UnsetEnv = F(
+	func(scope *Scope, args ...Object) Object {
+		if err := os.Unsetenv(args[0].AsString()); err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return False
+	},
+	"UnsetEnv",
+	"Removes the environment variable.",
+	P("name", V.Type(StringId)),
+)
+
+var Module_Os_Environ = F(
+	func(scope *Scope, args ...Object) Object {
+		env := NewDict(map[string]Object{})
+		for _, pair := range os.Environ() {
+			name, value, _ := strings.Cut(pair, "=")
+			env.Set(name, NewString(value))
+		}
+		return env
+	},
+	"Environ",
+	"Returns a dict with all environment variables.",
+)
+
+var Module_Os_LoadEnv = F(
+	func(scope *Scope, args ...Object) Object {
+		path := ".env"
+		if len(args) > 0 {
+			path = args[0].AsString()
+		}
+		override := len(args) > 1 && args[1].AsBool()
+
+		var file *os.File
+		var err error
+		scope.Blocking(func() {
+			file, err = os.Open(path)
+		})
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		defer file.Close()
+
+		loaded := NewDict(map[string]Object{})
+		scanner := bufio.NewScanner(file)
+		for n := 1; scanner.Scan(); n++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			line = strings.TrimPrefix(line, "export ")
+			name, value, ok := strings.Cut(line, "=")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return scope.Interrupt(Raise("invalid line %d of '%s': expected NAME=value", n, path))
+			}
+			value = envValue(strings.TrimSpace(value))
+
+			if _, set := os.LookupEnv(name); set && !override {
+				continue
+			}
+			os.Setenv(name, value)
+			loaded.Set(name, NewString(value))
+		}
+		if err := scanner.Err(); err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return loaded
+	},
+	"LoadEnv",
+	"Sets the environment variables of a file (`.env` by default) with `NAME=value` lines. Variables already set are kept, unless override is true. Returns a dict with the variables set.",
+	P("path"),
+	P("override"),
+)
+
+var Module_Os_Exit = F(
+	func(scope *Scope, args ...Object) Object {
+		code := 0
+		if len(args) > 0 {
+			num, ok := args[0].(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("exit code must be a number, got '%s'", args[0].TypeId()))
+			}
+			code = int(num.Value)
+		}
+		scope.Exit(code)
+		return False
+	},
+	"Exit",
+	"Exits the script immediately with the status code, 0 by default. Deferred calls are not run.",
+	P("code"),
+)
+
+var Module_Os_Cwd = F(
+	func(scope *Scope, args ...Object) Object {
+		dir, err := os.Getwd()
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return NewString(dir)
+	},
+	"Cwd",
+	"Returns the working directory.",
+)
+
+var Module_Os_Chdir = F(
+	func(scope *Scope, args ...Object) Object {
+		if err := os.Chdir(args[0].AsString()); err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return args[0]
+	},
+	"Chdir",
+	"Changes the working directory. Returns the path.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Os_Hostname = F(
+	func(scope *Scope, args ...Object) Object {
+		name, err := os.Hostname()
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return NewString(name)
+	},
+	"Hostname",
+	"Returns the host name of the machine.",
+)
+
+var Module_Os_Pid = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(os.Getpid()))
+	},
+	"Pid",
+	"Returns the process id of the interpreter.",
+)
+
+var Module_Os_Signals = F(
+	func(scope *Scope, args ...Object) Object {
+		names := []string{"SIGINT", "SIGTERM"}
+		if len(args) > 0 {
+			names = make([]string, len(args))
+			for i, arg := range args {
+				names[i] = arg.AsString()
+			}
+		}
+
+		signals := make([]os.Signal, len(names))
+		byValue := map[os.Signal]string{}
+		for i, name := range names {
+			name = strings.ToUpper(name)
+			if !strings.HasPrefix(name, "SIG") {
+				name = "SIG" + name
+			}
+
+			sig, ok := osSignals[name]
+			if !ok {
+				return scope.Interrupt(Raise("unknown signal '%s'", names[i]))
+			}
+			signals[i] = sig
+			byValue[sig] = name
+		}
+
+		// Signals are received from now on, even before the stream is pulled.
+		// If the script stops pulling them and the buffer fills up, they are
+		// handled by default again, and the one not buffered is sent again.
+		notified := make(chan os.Signal, 1)
+		ch := make(chan os.Signal, 16)
+		stop := make(chan struct{})
+		signal.Notify(notified, signals...)
+		go func() {
+			defer close(ch)
+			defer signal.Stop(notified)
+			for {
+				select {
+				case <-stop:
+					return
+				case sig := <-notified:
+					select {
+					case ch <- sig:
+					default:
+						signal.Stop(notified)
+						if p, err := os.FindProcess(os.Getpid()); err == nil {
+							p.Signal(sig)
+						}
+						return
+					}
+				}
+			}
+		}()
+
+		return NewInternalStream(func(s *Scope) Object {
+			var sig os.Signal
+			var ok bool
+			s.Blocking(func() {
+				sig, ok = <-ch
+			})
+			if !ok {
+				return nil
+			}
+			return YieldWith(NewString(byValue[sig]))
+		}, scope).OnClose(func(s *Scope) Object {
+			close(stop)
+			return nil
+		})
+	},
+	"Signals",
+	"Returns a stream of the names of the OS signals received, like `'SIGINT'`. Accepts the signals to listen to, SIGINT and SIGTERM by default, among SIGHUP, SIGINT, SIGQUIT and SIGTERM. While the stream is open and read, the signals do not stop the script. If 16 signals are left unread, the signals are handled by default again and the stream ends after the unread ones.",
+	P("signals", V.Type(StringId)).AsSpread(),
+)
+
+// Removes the quotes around the value of a .env line, or its trailing comment.
+func envValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		unquoted := value[1 : len(value)-1]
+		if value[0] == '"' {
+			unquoted = strings.ReplaceAll(unquoted, `\n`, "\n")
+		}
+		return unquoted
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
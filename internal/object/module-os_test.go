@@ -0,0 +1,76 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/renatopp/pipelang/internal/runtime"
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Os_Args(t *testing.T) {
+	r := runtime.New()
+	r.SetArgs([]string{"a", "b"})
+	obj, err := r.RunCode([]byte(` Os.Args()`))
+	if err != nil || obj.AsString() != `['a', 'b']` {
+		t.Errorf("unexpected result %v, %v", obj, err)
+	}
+
+	common.AssertCode(t, ` Os.Args()`, `[]`)
+}
+
+func TestModule_Os_Exit(t *testing.T) {
+	code := -1
+	r := runtime.New()
+	r.SetExit(func(c int) { code = c })
+
+	if _, err := r.RunCode([]byte(` Os.Exit(3)`)); err != nil || code != 3 {
+		t.Errorf("expected exit code 3, got %d (%v)", code, err)
+	}
+	if _, err := r.RunCode([]byte(` Os.Exit()`)); err != nil || code != 0 {
+		t.Errorf("expected exit code 0, got %d (%v)", code, err)
+	}
+	common.AssertCodeError(t, ` Os.Exit('a')`)
+}
+
+func TestModule_Os_Env(t *testing.T) {
+	t.Setenv("PIPE_TEST_ENV", "a")
+	common.AssertCode(t, ` Os.Env('PIPE_TEST_ENV').Value()`, `a`)
+	common.AssertCode(t, ` Os.Env('PIPE_TEST_MISSING').Ok()`, `false`)
+	common.AssertCode(t, ` Os.SetEnv('PIPE_TEST_ENV', 1); Os.Env('PIPE_TEST_ENV').Value()`, `1`)
+	common.AssertCode(t, ` Os.Environ()['PIPE_TEST_ENV']`, `1`)
+	common.AssertCode(t, ` Os.UnsetEnv('PIPE_TEST_ENV'); Os.Env('PIPE_TEST_ENV').Ok()`, `false`)
+}
+
+func TestModule_Os_LoadEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(path, []byte("# comment\nPIPE_TEST_A=1\nexport PIPE_TEST_B = 'two words'\nPIPE_TEST_C=3 # comment\nPIPE_TEST_D=\"a\\nb\"\n"), 0o644)
+	t.Setenv("PIPE_TEST_A", "0")
+	t.Setenv("PIPE_TEST_B", "")
+	t.Setenv("PIPE_TEST_C", "")
+	t.Setenv("PIPE_TEST_D", "")
+	os.Unsetenv("PIPE_TEST_B")
+	os.Unsetenv("PIPE_TEST_C")
+	os.Unsetenv("PIPE_TEST_D")
+
+	common.AssertCode(t, ` Os.LoadEnv('`+path+`'); Os.Env('PIPE_TEST_A').Value(), Os.Env('PIPE_TEST_B').Value(), Os.Env('PIPE_TEST_C').Value(), Os.Env('PIPE_TEST_D').Value()`, `('0', 'two words', '3', 'a\nb')`)
+	common.AssertCode(t, ` Os.LoadEnv('`+path+`', true); Os.Env('PIPE_TEST_A').Value()`, `1`)
+
+	os.WriteFile(path, []byte("INVALID\n"), 0o644)
+	common.AssertCodeError(t, ` Os.LoadEnv('`+path+`')`)
+	common.AssertCodeError(t, ` Os.LoadEnv('`+path+`.missing')`)
+}
+
+func TestModule_Os_Dirs(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer os.Chdir(cwd)
+
+	dir, _ := filepath.EvalSymlinks(t.TempDir())
+	common.AssertCode(t, ` Os.Chdir('`+dir+`'); Os.Cwd()`, dir)
+	common.AssertCodeError(t, ` Os.Chdir('`+dir+`/missing')`)
+
+	host, _ := os.Hostname()
+	common.AssertCode(t, ` Os.Hostname()`, host)
+	common.AssertCode(t, ` Os.Pid() > 0`, `true`)
+}
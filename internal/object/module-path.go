@@ -0,0 +1,170 @@
+package object
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var Module_Path = NewModuleType("Path")
+
+func init() {
+	Module_Path.SetProperty("Sep", Module_Path_Sep)
+
+	Module_Path.AddMethod(Module_Path_Join)
+	Module_Path.AddMethod(Module_Path_Base)
+	Module_Path.AddMethod(Module_Path_Dir)
+	Module_Path.AddMethod(Module_Path_Ext)
+	Module_Path.AddMethod(Module_Path_Stem)
+	Module_Path.AddMethod(Module_Path_Abs)
+	Module_Path.AddMethod(Module_Path_Rel)
+	Module_Path.AddMethod(Module_Path_Clean)
+	Module_Path.AddMethod(Module_Path_Split)
+	Module_Path.AddMethod(Module_Path_Match)
+	Module_Path.AddMethod(Module_Path_Expand)
+	Module_Path.AddMethod(Module_Path_WithExt)
+	Module_Path.AddMethod(Module_Path_WithName)
+	Module_Path.AddMethod(Module_Path_IsAbs)
+}
+
+// ----------------------------------------------------------------------------
+// The path is always the first argument, so the functions can be used as pipe
+// stages, like `path | Path.WithExt '.bak'`. Paths use the separator of the
+// operating system.
+// ----------------------------------------------------------------------------
+
+var Module_Path_Sep = NewString(string(filepath.Separator))
+
+func makePathFn(fn func(string) string, name string, desc string) *BuiltinFunction {
+	return F(
+		func(scope *Scope, args ...Object) Object {
+			return NewString(fn(args[0].AsString()))
+		},
+		name,
+		desc,
+		P("path", V.Type(StringId)),
+	)
+}
+
+var Module_Path_Base = makePathFn(filepath.Base, "Base", "Returns the last element of the path, like `'c.txt'` for `'a/b/c.txt'`.")
+var Module_Path_Dir = makePathFn(filepath.Dir, "Dir", "Returns the path without its last element, like `'a/b'` for `'a/b/c.txt'`.")
+var Module_Path_Ext = makePathFn(filepath.Ext, "Ext", "Returns the extension of the path, with the dot, like `'.txt'` for `'a/b/c.txt'`.")
+var Module_Path_Stem = makePathFn(pathStem, "Stem", "Returns the last element of the path without the extension, like `'c'` for `'a/b/c.txt'`.")
+var Module_Path_Clean = makePathFn(filepath.Clean, "Clean", "Returns the shortest path equivalent to the path, removing the `.` and `..` elements and repeated separators.")
+
+var Module_Path_Join = F(
+	func(scope *Scope, args ...Object) Object {
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			parts[i] = arg.AsString()
+		}
+		return NewString(filepath.Join(parts...))
+	},
+	"Join",
+	"Joins the elements with the separator, cleaning the result.",
+	P("parts", V.Type(StringId)).AsSpread(),
+)
+
+var Module_Path_Abs = F(
+	func(scope *Scope, args ...Object) Object {
+		path, err := filepath.Abs(args[0].AsString())
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return NewString(path)
+	},
+	"Abs",
+	"Returns the absolute version of the path, relative to the working directory.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Path_Rel = F(
+	func(scope *Scope, args ...Object) Object {
+		path, err := filepath.Rel(args[1].AsString(), args[0].AsString())
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		return NewString(path)
+	},
+	"Rel",
+	"Returns the path relative to the base, like `'b/c.txt'` for `'a/b/c.txt'` and `'a'`. Raises if it cannot be made relative.",
+	P("path", V.Type(StringId)),
+	P("base", V.Type(StringId)),
+)
+
+var Module_Path_Split = F(
+	func(scope *Scope, args ...Object) Object {
+		dir, file := filepath.Split(args[0].AsString())
+		return NewTuple(NewString(dir), NewString(file))
+	},
+	"Split",
+	"Splits the path after its last separator, returning a (dir, file) tuple, like `('a/b/', 'c.txt')`.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Path_Match = F(
+	func(scope *Scope, args ...Object) Object {
+		pattern := strings.Split(filepath.ToSlash(args[1].AsString()), "/")
+		path := strings.Split(filepath.ToSlash(args[0].AsString()), "/")
+		return NewBoolean(globMatch(pattern, path))
+	},
+	"Match",
+	"Returns true if the path matches the glob pattern. Besides the `*`, `?` and `[...]` wildcards, `**` matches any number of directories.",
+	P("path", V.Type(StringId)),
+	P("pattern", V.Type(StringId)),
+)
+
+var Module_Path_Expand = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+		if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return scope.Interrupt(Raise("%s", err.Error()))
+			}
+			path = home + path[1:]
+		}
+		return NewString(os.ExpandEnv(path))
+	},
+	"Expand",
+	"Replaces the leading `~` by the home directory and the `$VAR` and `${VAR}` environment variables by their values.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Path_WithExt = F(
+	func(scope *Scope, args ...Object) Object {
+		path, ext := args[0].AsString(), args[1].AsString()
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		return NewString(strings.TrimSuffix(path, filepath.Ext(path)) + ext)
+	},
+	"WithExt",
+	"Returns the path with the extension replaced, like `'a/c.bak'` for `'a/c.txt'` and `'.bak'`. The dot is optional, and an empty extension removes it.",
+	P("path", V.Type(StringId)),
+	P("ext", V.Type(StringId)),
+)
+
+var Module_Path_WithName = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewString(filepath.Join(filepath.Dir(args[0].AsString()), args[1].AsString()))
+	},
+	"WithName",
+	"Returns the path with the last element replaced, like `'a/d.txt'` for `'a/c.txt'` and `'d.txt'`.",
+	P("path", V.Type(StringId)),
+	P("name", V.Type(StringId)),
+)
+
+var Module_Path_IsAbs = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewBoolean(filepath.IsAbs(args[0].AsString()))
+	},
+	"IsAbs",
+	"Returns true if the path is absolute.",
+	P("path", V.Type(StringId)),
+)
+
+func pathStem(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
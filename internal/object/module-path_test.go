@@ -0,0 +1,42 @@
+package object_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Path(t *testing.T) {
+	common.AssertCode(t, ` Path.Join('a', 'b/', '../c', 'd.txt')`, `a/c/d.txt`)
+	common.AssertCode(t, ` Path.Base('a/b/c.txt'), Path.Dir('a/b/c.txt'), Path.Ext('a/b/c.txt'), Path.Stem('a/b/c.tar.gz')`, `('c.txt', 'a/b', '.txt', 'c.tar')`)
+	common.AssertCode(t, ` Path.Clean('a//b/./../c/')`, `a/c`)
+	common.AssertCode(t, ` Path.Split('a/b/c.txt')`, `('a/b/', 'c.txt')`)
+	common.AssertCode(t, ` Path.Rel('/a/b/c.txt', '/a'), Path.Rel('/a/c', '/a/b')`, `('b/c.txt', '../c')`)
+	common.AssertCodeError(t, ` Path.Rel('a/b', '/a')`)
+	common.AssertCode(t, ` Path.IsAbs('/a'), Path.IsAbs('a'), Path.IsAbs(Path.Abs('a'))`, `(true, false, true)`)
+	common.AssertCode(t, ` Path.Sep`, `/`)
+}
+
+func TestModule_Path_Replace(t *testing.T) {
+	common.AssertCode(t, ` Path.WithExt('a/c.txt', '.bak'), Path.WithExt('a/c.txt', 'md'), Path.WithExt('a/c.txt', '')`, `('a/c.bak', 'a/c.md', 'a/c')`)
+	common.AssertCode(t, ` Path.WithName('a/c.txt', 'd.txt'), Path.WithName('c.txt', 'd.txt')`, `('a/d.txt', 'd.txt')`)
+	common.AssertCode(t, ` 'a/c.txt' | Path.WithExt '.bak'`, `a/c.bak`)
+}
+
+func TestModule_Path_Match(t *testing.T) {
+	common.AssertCode(t, ` Path.Match('a/b.jpg', 'a/*.jpg'), Path.Match('a/b/c.jpg', 'a/*.jpg')`, `(true, false)`)
+	common.AssertCode(t, ` Path.Match('a/b/c.jpg', '**/*.jpg'), Path.Match('c.jpg', '**/*.jpg'), Path.Match('a/b/c.png', 'a/**')`, `(true, true, true)`)
+}
+
+func TestModule_Path_Expand(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	t.Setenv("PIPE_TEST_DIR", "logs")
+	common.AssertCode(t, ` Path.Expand('~/$PIPE_TEST_DIR/${PIPE_TEST_DIR}.txt')`, home+`/logs/logs.txt`)
+	common.AssertCode(t, ` Path.Expand('a~/b')`, `a~/b`)
+}
+
+func TestModule_Path_Pipes(t *testing.T) {
+	common.AssertCode(t, ` ['a/b.txt', 'c/d.jpg'] | map Path.Base | List`, `['b.txt', 'd.jpg']`)
+	common.AssertCode(t, ` ['a/b.txt', 'c/d.jpg'] | map Path.Stem | List`, `['b', 'd']`)
+}
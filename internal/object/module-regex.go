@@ -0,0 +1,53 @@
+package object
+
+import (
+	"regexp"
+	"strings"
+)
+
+var Module_Regex = NewModuleType("Regex")
+
+func init() {
+	Module_Regex.AddMethod(Module_Regex_Compile)
+	Module_Regex.AddMethod(Module_Regex_Escape)
+}
+
+// ----------------------------------------------------------------------------
+// Patterns use the syntax of Go's regexp package (RE2). Raw strings keep the
+// backslashes, so they are the easiest way to write them:
+// `Regex.Compile(r'(\d+)-(\d+)')`.
+// ----------------------------------------------------------------------------
+
+var Module_Regex_Compile = F(
+	func(scope *Scope, args ...Object) Object {
+		pattern := args[0].AsString()
+		if len(args) > 1 {
+			flags := args[1].AsString()
+			if strings.Trim(flags, "imsU") != "" {
+				return scope.Interrupt(Raise("invalid regex flags '%s', expected any of i, m, s and U", flags))
+			}
+			if flags != "" {
+				pattern = "(?" + flags + ")" + pattern
+			}
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return scope.Interrupt(Raise("invalid regex: %s", err.Error()))
+		}
+		return NewPattern(re)
+	},
+	"Compile",
+	"Compiles the regular expression into a pattern. The flags are `i` (case insensitive), `m` (multi-line: `^` and `$` match at line breaks), `s` (`.` matches line breaks) and `U` (ungreedy).",
+	P("pattern", V.Type(StringId)),
+	P("flags"),
+)
+
+var Module_Regex_Escape = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewString(regexp.QuoteMeta(args[0].AsString()))
+	},
+	"Escape",
+	"Returns the text with the special characters of regular expressions escaped, so it matches the text literally.",
+	P("text", V.Type(StringId)),
+)
@@ -0,0 +1,46 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Regex_Compile(t *testing.T) {
+	common.AssertCode(t, " Regex.Compile(r'\\d+').pattern", `\d+`)
+	common.AssertCode(t, " Regex.Compile('a.b', 'is').Match('A\\nB')", `true`)
+	common.AssertCode(t, " Pattern('x').Match('axb')", `true`)
+	common.AssertCode(t, " Regex.Escape('a.b*c')", `a\.b\*c`)
+	common.AssertCode(t, " Regex.Compile(Regex.Escape('1+1')).Match('1+1=2')", `true`)
+	common.AssertCodeError(t, " Regex.Compile('(')")
+	common.AssertCodeError(t, " Regex.Compile('a', 'x')")
+}
+
+func TestPattern_Find(t *testing.T) {
+	common.AssertCode(t, " r := Regex.Compile(r'(?P<key>\\w+)=(\\w+)'); m := r.Find('--- a=1 b=2').Value(); m.text, m.start, m.end", `('a=1', 4, 7)`)
+	common.AssertCode(t, " r := Regex.Compile(r'(?P<key>\\w+)=(\\w+)'); m := r.Find('a=1').Value(); m.groups, m.named", `(['a', '1'], {key=a})`)
+	common.AssertCode(t, " r := Regex.Compile(r'(?P<key>\\w+)=(\\w+)'); m := r.Find('a=1').Value(); m.Group(0), m.Group(2), m.Group('key')", `('a=1', '1', 'a')`)
+	common.AssertCode(t, " r := Regex.Compile(r'(a)|(b)'); r.Find('b').Value().groups", `['', 'b']`)
+	common.AssertCode(t, " Regex.Compile('x').Find('abc').Ok()", `false`)
+	common.AssertCode(t, " Regex.Compile(r'(?P<a>.)(?P<b>.)').Names()", `['a', 'b']`)
+	common.AssertCodeError(t, " Regex.Compile('(a)').Find('a').Value().Group(2)")
+}
+
+func TestPattern_FindAll(t *testing.T) {
+	common.AssertCode(t, " Regex.Compile(r'\\d+').FindAll('a1 b22 c333') | map (m: m.text) | List", `['1', '22', '333']`)
+	common.AssertCode(t, " Regex.Compile(r'b+').FindAll('ébb çb') | map (m: m.start) | List", `[1, 5]`)
+	common.AssertCode(t, " Regex.Compile(r'\\d').FindAll('123') | take 2 | List", `['1', '2']`)
+	common.AssertCode(t, " Regex.Compile('x').FindAll('abc') | count", `0`)
+}
+
+func TestPattern_Replace(t *testing.T) {
+	common.AssertCode(t, " Regex.Compile(r'(?P<n>\\d+)').Replace('a1 b2', '<${n}>')", `a<1> b<2>`)
+	common.AssertCode(t, " Regex.Compile(r'\\d+').Replace('a1 b2 c3', 'x', 2)", `ax bx c3`)
+	common.AssertCode(t, " Regex.Compile(r'\\d+').Replace('é1 ç22', (m: m.start))", `é1 ç4`)
+	common.AssertCodeError(t, " Regex.Compile(r'\\d+').Replace('a1', (m: raise 'no'))")
+}
+
+func TestPattern_Split(t *testing.T) {
+	common.AssertCode(t, " Regex.Compile(r'\\s*,\\s*').Split('a , b,c')", `['a', 'b', 'c']`)
+	common.AssertCode(t, " Regex.Compile(r',').Split('a,b,c', 2)", `['a', 'b,c']`)
+}
@@ -0,0 +1,258 @@
+package object
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var Module_Xml = NewModuleType("Xml")
+
+func init() {
+	Module_Xml.AddMethod(Module_Xml_Parse)
+	Module_Xml.AddMethod(Module_Xml_Stringify)
+	Module_Xml.AddMethod(Module_Xml_Elements)
+}
+
+// ----------------------------------------------------------------------------
+// Documents are trees of `XmlElement`, with the properties `tag`, `attributes`
+// (a dict), `children` (a list of elements) and `text`, the text directly
+// inside the element without the surrounding whitespace. Comments and
+// processing instructions are dropped. Prefixed names are kept as they are
+// written, like `atom:link`.
+// ----------------------------------------------------------------------------
+
+var Module_Xml_Parse = F(
+	func(scope *Scope, args ...Object) Object {
+		decoder := xml.NewDecoder(strings.NewReader(args[0].AsString()))
+
+		var root *xmlNode
+		for {
+			token, err := decoder.RawToken()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return scope.Interrupt(Raise("invalid xml: %s", err.Error()))
+			}
+
+			switch token := token.(type) {
+			case xml.StartElement:
+				if root != nil {
+					return scope.Interrupt(Raise("invalid xml: more than one root element"))
+				}
+				if root, err = decodeXmlNode(decoder, token); err != nil {
+					return scope.Interrupt(Raise("invalid xml: %s", err.Error()))
+				}
+			case xml.EndElement:
+				return scope.Interrupt(Raise("invalid xml: unexpected </%s>", xmlName(token.Name)))
+			case xml.CharData:
+				if root != nil && strings.TrimSpace(string(token)) != "" {
+					return scope.Interrupt(Raise("invalid xml: text after the root element"))
+				}
+			}
+		}
+
+		if root == nil {
+			return scope.Interrupt(Raise("invalid xml: no root element"))
+		}
+		return root.element()
+	},
+	"Parse",
+	"Parses the XML text, returning its root element.",
+	P("text", V.Type(StringId)),
+)
+
+var Module_Xml_Stringify = F(
+	func(scope *Scope, args ...Object) Object {
+		el, ok := args[0].(*XmlElement)
+		if !ok {
+			return scope.Interrupt(Raise("expected an 'XmlElement', got '%s'", args[0].TypeId()))
+		}
+
+		indent := ""
+		if len(args) > 1 {
+			opts, ok := args[1].(*Dict)
+			if !ok {
+				return scope.Interrupt(Raise("expected a dict of options, got '%s'", args[1].TypeId()))
+			}
+			for key, value := range opts.Elements {
+				switch key {
+				case "indent":
+					if num, ok := value.(*Number); ok {
+						indent = strings.Repeat(" ", int(num.Value))
+					} else {
+						indent = value.AsString()
+					}
+				default:
+					return scope.Interrupt(Raise("unknown option '%s', expected indent", key))
+				}
+			}
+		}
+
+		b := &strings.Builder{}
+		writeXml(b, el, indent, 0)
+		return NewString(b.String())
+	},
+	"Stringify",
+	"Returns the element as XML text. The option `indent`, a number of spaces or a string, puts each element in its own line.",
+	P("element"),
+	P("options"),
+)
+
+var Module_Xml_Elements = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+		tag := args[1].AsString()
+
+		var file *os.File
+		var decoder *xml.Decoder
+		return NewInternalStream(func(s *Scope) Object {
+			var node *xmlNode
+			var err error
+			s.Blocking(func() {
+				if file == nil {
+					if file, err = os.Open(path); err != nil {
+						return
+					}
+					decoder = xml.NewDecoder(bufio.NewReader(file))
+				}
+
+				for {
+					var token xml.Token
+					if token, err = decoder.RawToken(); err != nil {
+						return
+					}
+					if start, ok := token.(xml.StartElement); ok && xmlName(start.Name) == tag {
+						node, err = decodeXmlNode(decoder, start)
+						return
+					}
+				}
+			})
+
+			switch {
+			case err == io.EOF:
+				return nil
+			case err != nil:
+				return s.Interrupt(Raise("invalid xml in '%s': %s", path, err.Error()))
+			}
+			return YieldWith(node.element())
+		}, scope).OnClose(func(s *Scope) Object {
+			if file != nil {
+				file.Close()
+			}
+			return nil
+		})
+	},
+	"Elements",
+	"Returns a stream of the elements of the XML file with the tag, like the items of a feed, reading one element at a time, so huge files can be read with little memory. Elements inside a matching element are not yielded again.",
+	P("path", V.Type(StringId)),
+	P("tag", V.Type(StringId)),
+)
+
+// xmlNode is a decoded element, converted to an `XmlElement` afterwards, so
+// the documents can be decoded without holding the interpreter lock.
+type xmlNode struct {
+	tag      string
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     strings.Builder
+}
+
+// Decodes the element opened by the start token, with all its children.
+// The raw tokens keep the namespace prefixes, but the decoder does not check
+// that the tags match, so it is done here.
+func decodeXmlNode(decoder *xml.Decoder, start xml.StartElement) (*xmlNode, error) {
+	node := &xmlNode{tag: xmlName(start.Name), attrs: start.Attr}
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return nil, fmt.Errorf("unclosed <%s>", node.tag)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXmlNode(decoder, token)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		case xml.EndElement:
+			if name := xmlName(token.Name); name != node.tag {
+				return nil, fmt.Errorf("expected </%s>, got </%s>", node.tag, name)
+			}
+			return node, nil
+		case xml.CharData:
+			node.text.Write(token)
+		}
+	}
+}
+
+func (n *xmlNode) element() *XmlElement {
+	attributes := NewDict(map[string]Object{})
+	for _, attr := range n.attrs {
+		attributes.Set(xmlName(attr.Name), NewString(attr.Value))
+	}
+
+	children := make([]Object, len(n.children))
+	for i, child := range n.children {
+		children[i] = child.element()
+	}
+	return NewXmlElement(n.tag, attributes, NewList(children...), strings.TrimSpace(n.text.String()))
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+func writeXml(b *strings.Builder, el *XmlElement, indent string, depth int) {
+	prefix := strings.Repeat(indent, depth)
+	b.WriteString(prefix + "<" + el.Tag())
+	attributes := el.Attributes()
+	for _, name := range attributes.Keys() {
+		b.WriteString(" " + name + `="`)
+		xmlEscape(b, attributes.Elements[name].AsString())
+		b.WriteString(`"`)
+	}
+
+	children, text := el.Children(), el.Text()
+	switch {
+	case len(children) == 0 && text == "":
+		b.WriteString("/>")
+		return
+
+	case len(children) == 0:
+		b.WriteString(">")
+		xmlEscape(b, text)
+
+	default:
+		b.WriteString(">")
+		if indent != "" && text != "" {
+			b.WriteString("\n" + prefix + indent)
+		}
+		xmlEscape(b, text)
+		for _, child := range children {
+			if indent != "" {
+				b.WriteString("\n")
+			}
+			writeXml(b, child, indent, depth+1)
+		}
+		if indent != "" {
+			b.WriteString("\n" + prefix)
+		}
+	}
+	b.WriteString("</" + el.Tag() + ">")
+}
+
+func xmlEscape(b *strings.Builder, s string) {
+	xml.EscapeText(b, []byte(s))
+}
@@ -0,0 +1,69 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+var junit = "Xml.Parse('" + strings.ReplaceAll(`<?xml version="1.0"?>
+<testsuites>
+  <!-- report -->
+  <testsuite name="a" tests="2">
+    <testcase name="ok" status="passed"/>
+    <testcase name="bad"><failure message="x &amp; y">trace</failure></testcase>
+  </testsuite>
+  <testsuite name="b"><testcase name="c" status="skipped"/></testsuite>
+</testsuites>`, "\n", `\n`) + "')"
+
+func TestModule_Xml_Parse(t *testing.T) {
+	common.AssertCode(t, ` `+junit+`.tag`, `testsuites`)
+	common.AssertCode(t, ` `+junit+`.children | map (s: s.attributes['name']) | List`, `['a', 'b']`)
+	common.AssertCode(t, ` Xml.Parse('<a x="1" b="2"> hi <c/></a>').attributes`, `{x=1, b=2}`)
+	common.AssertCode(t, ` Xml.Parse('<a x="1" b="2"> hi <c/></a>').text`, `hi`)
+	common.AssertCode(t, ` Xml.Parse('<a:b xmlns:a="u"><a:c/></a:b>').children[0].tag`, `a:c`)
+	common.AssertCodeError(t, ` Xml.Parse('<a><b></a>')`)
+	common.AssertCodeError(t, ` Xml.Parse('<a/><b/>')`)
+	common.AssertCodeError(t, ` Xml.Parse('text')`)
+}
+
+func TestXmlElement_Find(t *testing.T) {
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('testsuite/testcase[@status]') | map (c: c.Attr('name')) | List`, `['ok', 'c']`)
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('testsuite/testcase[@status="skipped"]') | map (c: c.Attr('name')) | List`, `['c']`)
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('*/testcase[failure]') | map (c: c.Attr('name')) | List`, `['bad']`)
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('//failure') | map (f: f.Attr('message')) | List`, `['x & y']`)
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('testsuite[1]/testcase[2]') | map (c: c.Attr('name')) | List`, `['bad']`)
+	common.AssertCode(t, ` r := `+junit+`; r.FindAll('testsuite[@name="a"]//failure')[0].text`, `trace`)
+	common.AssertCode(t, ` r := `+junit+`; r.Find('testsuite').Value().Attr('tests')`, `2`)
+	common.AssertCode(t, ` r := `+junit+`; r.Find('nothing').Ok()`, `false`)
+	common.AssertCode(t, ` r := `+junit+`; r.Attr('missing', 'x')`, `x`)
+	common.AssertCodeError(t, ` Xml.Parse('<a/>').FindAll('b[@x')`)
+	common.AssertCodeError(t, ` Xml.Parse('<a/>').FindAll('b/')`)
+}
+
+func TestModule_Xml_Stringify(t *testing.T) {
+	common.AssertCode(t, ` Xml.Stringify(Xml.Parse('<a x="1 &lt; 2">t<b/><c>d</c></a>'))`, `<a x="1 &lt; 2">t<b/><c>d</c></a>`)
+	common.AssertCode(t, ` Xml.Stringify(Xml.Parse('<a><b>c</b><d/></a>'), {indent=2})`, "<a>\n  <b>c</b>\n  <d/>\n</a>")
+	common.AssertCode(t, ` e := XmlElement { tag='item', text='a & b' }; e.attributes['id'] = 1; Xml.Stringify(e)`, `<item id="1">a &amp; b</item>`)
+	common.AssertCode(t, ` r := Xml.Parse('<a/>'); r.children.Push(XmlElement { tag='b' }); Xml.Stringify(r)`, `<a><b/></a>`)
+	common.AssertCodeError(t, ` Xml.Stringify(1)`)
+}
+
+func TestModule_Xml_Elements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	os.WriteFile(path, []byte(`<rss><channel><title>t</title>
+		<item><title>a</title></item>
+		<item><title>b</title><item><title>nested</title></item></item>
+	</channel></rss>`), 0o644)
+
+	common.AssertCode(t, ` Xml.Elements('`+path+`', 'item') | map (i: i.Find('title').Value().text) | List`, `['a', 'b']`)
+	common.AssertCode(t, ` Xml.Elements('`+path+`', 'item') | take 1 | count`, `1`)
+	common.AssertCode(t, ` Xml.Elements('`+path+`', 'missing') | count`, `0`)
+
+	os.WriteFile(path, []byte(`<rss><item><title>a</title></item><item>`), 0o644)
+	common.AssertCode(t, ` Xml.Elements('`+path+`', 'item') | take 1 | count`, `1`)
+	common.AssertCodeError(t, ` Xml.Elements('`+path+`', 'item') | List`)
+}
@@ -0,0 +1,464 @@
+package object
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var Module_Yaml = NewModuleType("Yaml")
+
+func init() {
+	Module_Yaml.SetProperty("Null", Module_Yaml_Null)
+
+	Module_Yaml.AddMethod(Module_Yaml_Parse)
+	Module_Yaml.AddMethod(Module_Yaml_Stringify)
+	Module_Yaml.AddMethod(Module_Yaml_Documents)
+	Module_Yaml.AddMethod(Module_Yaml_WriteDocuments)
+}
+
+// ----------------------------------------------------------------------------
+// Mappings become dicts, keeping the order of the keys, sequences become lists
+// and null becomes `Yaml.Null`, an empty Maybe. Anchors and merge keys (`<<`)
+// are resolved, and timestamps are kept as strings. When stringifying, the
+// values are converted like in the Json module.
+// ----------------------------------------------------------------------------
+
+var Module_Yaml_Null = NewMaybe(NewErrorFromString("null"))
+
+var Module_Yaml_Parse = F(
+	func(scope *Scope, args ...Object) Object {
+		decoder := yaml.NewDecoder(strings.NewReader(args[0].AsString()))
+
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if err == io.EOF {
+			return Module_Yaml_Null
+		}
+		if err == nil {
+			if err = decoder.Decode(&yaml.Node{}); err == nil {
+				err = errors.New("expected a single document, use 'Yaml.Documents' for more")
+			} else if err == io.EOF {
+				err = nil
+			}
+		}
+		if err != nil {
+			return scope.Interrupt(Raise("invalid yaml: %s", err.Error()))
+		}
+
+		value, err := decodeYaml(&node)
+		if err != nil {
+			return scope.Interrupt(Raise("invalid yaml: %s", err.Error()))
+		}
+		return value
+	},
+	"Parse",
+	"Parses the YAML text, which must have a single document.",
+	P("text", V.Type(StringId)),
+)
+
+var Module_Yaml_Stringify = F(
+	func(scope *Scope, args ...Object) Object {
+		opts, err := yamlOptionsOf(scope, args[1:])
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		encoder := opts.encoder(buf)
+		if err := encodeYamlDocument(scope, encoder, args[0], opts.sortKeys); err != nil {
+			return err
+		}
+		encoder.Close()
+		return NewString(buf.String())
+	},
+	"Stringify",
+	"Returns the value as YAML text. The options are `indent`, the number of spaces of each level, 2 by default, and `sortKeys`, which sorts the keys of the mappings.",
+	P("value"),
+	P("options"),
+)
+
+var Module_Yaml_Documents = F(
+	func(scope *Scope, args ...Object) Object {
+		path := args[0].AsString()
+
+		var file *os.File
+		var decoder *yaml.Decoder
+		return NewInternalStream(func(s *Scope) Object {
+			for {
+				var node yaml.Node
+				var err error
+				s.Blocking(func() {
+					if file == nil {
+						if file, err = os.Open(path); err != nil {
+							return
+						}
+						decoder = yaml.NewDecoder(file)
+					}
+					err = decoder.Decode(&node)
+				})
+
+				if err == io.EOF {
+					return nil
+				}
+				if err == nil && isEmptyYamlDocument(&node) {
+					continue
+				}
+				var value Object
+				if err == nil {
+					value, err = decodeYaml(&node)
+				}
+				if err != nil {
+					return s.Interrupt(Raise("invalid yaml in '%s': %s", path, err.Error()))
+				}
+				return YieldWith(value)
+			}
+		}, scope).OnClose(func(s *Scope) Object {
+			if file != nil {
+				file.Close()
+			}
+			return nil
+		})
+	},
+	"Documents",
+	"Returns a stream of the documents of the YAML file, separated by `---`, decoding one document at a time. Empty documents are skipped.",
+	P("path", V.Type(StringId)),
+)
+
+var Module_Yaml_WriteDocuments = F(
+	func(scope *Scope, args ...Object) Object {
+		s := StreamTypeObj.Convert(scope, args[0])
+		if isRaise(s) {
+			return s
+		}
+		stream := s.(*Stream)
+
+		opts, ret := yamlOptionsOf(scope, args[2:])
+		if ret != nil {
+			return ret
+		}
+
+		path := args[1].AsString()
+		var file *os.File
+		var err error
+		scope.Blocking(func() {
+			file, err = os.Create(path)
+		})
+		if err != nil {
+			return fileError(scope, err)
+		}
+
+		buf := &bytes.Buffer{}
+		encoder := opts.encoder(buf)
+		count := 0
+		ret = stream.Resolve(func(value Object) Object {
+			if err := encodeYamlDocument(scope, encoder, streamValue(stream, value), opts.sortKeys); err != nil {
+				return err
+			}
+
+			// Writes what was encoded so far, so the documents are not kept in memory
+			data := buf.Bytes()
+			scope.Blocking(func() {
+				_, err = file.Write(data)
+			})
+			buf.Reset()
+			if err != nil {
+				return fileError(scope, err)
+			}
+			count++
+			return nil
+		})
+
+		encoder.Close()
+		scope.Blocking(func() {
+			_, werr := file.Write(buf.Bytes())
+			err = errors.Join(werr, file.Close())
+		})
+		if isRaise(ret) {
+			return ret
+		}
+		if err != nil {
+			return fileError(scope, err)
+		}
+		return NewNumber(float64(count))
+	},
+	"WriteDocuments",
+	"Writes each element of the stream as a document of the YAML file, replacing it if it exists. Accepts the options of `Yaml.Stringify`. Returns the number of documents written.",
+	P("stream"),
+	P("path", V.Type(StringId)),
+	P("options"),
+)
+
+type yamlOptions struct {
+	indent   int
+	sortKeys bool
+}
+
+// Returns the options of the optional dict at the end of the arguments.
+func yamlOptionsOf(scope *Scope, args []Object) (*yamlOptions, Object) {
+	opts := &yamlOptions{indent: 2}
+	if len(args) == 0 {
+		return opts, nil
+	}
+
+	dict, ok := args[0].(*Dict)
+	if !ok {
+		return nil, scope.Interrupt(Raise("expected a dict of options, got '%s'", args[0].TypeId()))
+	}
+	for key, value := range dict.Elements {
+		switch key {
+		case "indent":
+			num, ok := value.(*Number)
+			if !ok || num.Value < 1 {
+				return nil, scope.Interrupt(Raise("option 'indent' must be a positive number, got '%s'", value.AsString()))
+			}
+			opts.indent = int(num.Value)
+		case "sortKeys":
+			opts.sortKeys = value.AsBool()
+		default:
+			return nil, scope.Interrupt(Raise("unknown option '%s', expected indent or sortKeys", key))
+		}
+	}
+	return opts, nil
+}
+
+func (opts *yamlOptions) encoder(w io.Writer) *yaml.Encoder {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(opts.indent)
+	return encoder
+}
+
+// Returns true for documents without content, like the one after a trailing
+// `---`.
+func isEmptyYamlDocument(node *yaml.Node) bool {
+	if len(node.Content) == 0 {
+		return true
+	}
+	content := node.Content[0]
+	return content.Kind == yaml.ScalarNode && content.Tag == "!!null" && content.Value == ""
+}
+
+func decodeYaml(node *yaml.Node) (Object, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return Module_Yaml_Null, nil
+		}
+		return decodeYaml(node.Content[0])
+
+	case yaml.AliasNode:
+		return decodeYaml(node.Alias)
+
+	case yaml.SequenceNode:
+		list := make([]Object, len(node.Content))
+		for i, child := range node.Content {
+			value, err := decodeYaml(child)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return NewList(list...), nil
+
+	case yaml.MappingNode:
+		dict := NewDict(map[string]Object{})
+		if err := decodeYamlMapping(dict, node); err != nil {
+			return nil, err
+		}
+		return dict, nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case nil:
+		return Module_Yaml_Null, nil
+	case bool:
+		return NewBoolean(value), nil
+	case int:
+		return NewNumber(float64(value)), nil
+	case uint64:
+		return NewNumber(float64(value)), nil
+	case float64:
+		return NewNumber(value), nil
+	}
+	return NewString(node.Value), nil
+}
+
+// Adds the pairs of the mapping to the dict. The pairs of merge keys come
+// first, so the keys of the mapping override them.
+func decodeYamlMapping(dict *Dict, node *yaml.Node) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, child := node.Content[i], node.Content[i+1]
+		if key.Tag != "!!merge" {
+			continue
+		}
+
+		merged := []*yaml.Node{child}
+		if child.Kind == yaml.SequenceNode {
+			merged = child.Content
+		}
+		for _, m := range merged {
+			if m.Kind == yaml.AliasNode {
+				m = m.Alias
+			}
+			if m.Kind != yaml.MappingNode {
+				return errors.New("merge key must be a mapping or a list of mappings")
+			}
+			if err := decodeYamlMapping(dict, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, child := node.Content[i], node.Content[i+1]
+		if key.Tag == "!!merge" {
+			continue
+		}
+
+		if key.Kind == yaml.AliasNode {
+			key = key.Alias
+		}
+		if key.Kind != yaml.ScalarNode {
+			return errors.New("keys must be scalars at line " + strconv.Itoa(key.Line))
+		}
+		value, err := decodeYaml(child)
+		if err != nil {
+			return err
+		}
+		dict.Set(key.Value, value)
+	}
+	return nil
+}
+
+func encodeYamlDocument(scope *Scope, encoder *yaml.Encoder, obj Object, sortKeys bool) Object {
+	node, ret := encodeYaml(scope, obj, sortKeys)
+	if ret != nil {
+		return ret
+	}
+	if err := encoder.Encode(node); err != nil {
+		return scope.Interrupt(Raise("cannot convert to yaml: %s", err.Error()))
+	}
+	return nil
+}
+
+func encodeYaml(scope *Scope, obj Object, sortKeys bool) (*yaml.Node, Object) {
+	switch obj := obj.(type) {
+	case *Number:
+		tag := "!!float"
+		if obj.Value == math.Trunc(obj.Value) && math.Abs(obj.Value) < 1e15 {
+			tag = "!!int"
+		}
+		return yamlScalar(tag, yamlNumber(obj.Value)), nil
+
+	case *Boolean:
+		return yamlScalar("!!bool", strconv.FormatBool(obj.Value)), nil
+
+	case *String:
+		node := yamlScalar("!!str", obj.Value)
+		switch {
+		case strings.Contains(obj.Value, "\n"):
+			node.Style = yaml.LiteralStyle
+		case yamlOldBools[obj.Value] || yamlBase60.MatchString(obj.Value):
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		return node, nil
+
+	case *Time:
+		return yamlScalar("!!timestamp", obj.AsString()), nil
+
+	case *Maybe:
+		if !obj.Ok {
+			return yamlScalar("!!null", "null"), nil
+		}
+		return encodeYaml(scope, obj.Value, sortKeys)
+
+	case *List:
+		return encodeYamlSequence(scope, obj.Elements, sortKeys)
+
+	case *Tuple:
+		return encodeYamlSequence(scope, obj.Elements, sortKeys)
+
+	case *Stream:
+		list := ListTypeObj.Convert(scope, obj)
+		if isRaise(list) {
+			return nil, list
+		}
+		return encodeYamlSequence(scope, list.(*List).Elements, sortKeys)
+
+	case *Dict:
+		return encodeYamlMapping(scope, obj, sortKeys)
+
+	case *Data:
+		return encodeYamlMapping(scope, recordToDict(scope, obj).(*Dict), sortKeys)
+	}
+
+	return nil, scope.Interrupt(Raise("cannot convert '%s' to yaml", obj.TypeId()))
+}
+
+func encodeYamlSequence(scope *Scope, elements []Object, sortKeys bool) (*yaml.Node, Object) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, e := range elements {
+		child, err := encodeYaml(scope, e, sortKeys)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, child)
+	}
+	return node, nil
+}
+
+func encodeYamlMapping(scope *Scope, dict *Dict, sortKeys bool) (*yaml.Node, Object) {
+	keys := dict.Keys()
+	if sortKeys {
+		slices.Sort(keys)
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range keys {
+		child, err := encodeYaml(scope, dict.Elements[key], sortKeys)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, yamlScalar("!!str", dict.KeyOf(key).AsString()), child)
+	}
+	return node, nil
+}
+
+// Strings read as booleans or base 60 numbers by YAML 1.1 parsers, which are
+// quoted like yaml.v3 does when marshalling.
+var yamlOldBools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true,
+	"on": true, "On": true, "ON": true,
+	"off": true, "Off": true, "OFF": true,
+}
+
+var yamlBase60 = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?$`)
+
+func yamlScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+func yamlNumber(n float64) string {
+	switch {
+	case math.IsNaN(n):
+		return ".nan"
+	case math.IsInf(n, 1):
+		return ".inf"
+	case math.IsInf(n, -1):
+		return "-.inf"
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
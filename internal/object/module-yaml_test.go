@@ -0,0 +1,51 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestModule_Yaml_Parse(t *testing.T) {
+	common.AssertCode(t, ` Yaml.Parse('b: 1\na: [x, 2.5, true]\nc:').Keys()`, `['b', 'a', 'c']`)
+	common.AssertCode(t, ` Yaml.Parse('b: 1\na: [x, 2, true]')['a']`, `['x', 2, true]`)
+	common.AssertCode(t, ` Yaml.Parse('a: null')['a'].Ok()`, `false`)
+	common.AssertCode(t, ` Yaml.Parse('a: "1"\nb: 2024-01-02')`, `{a=1, b=2024-01-02}`)
+	common.AssertCode(t, ` Yaml.Parse('a: "1"')['a'] == '1'`, `true`)
+	common.AssertCode(t, ` Yaml.Parse('base: &b {x: 1, y: 2}\nc:\n  <<: *b\n  y: 3')['c']`, `{x=1, y=3}`)
+	common.AssertCode(t, ` Yaml.Parse('- |\n  a\n  b\n')[0]`, "a\nb\n")
+	common.AssertCode(t, ` Yaml.Parse('').Ok()`, `false`)
+	common.AssertCodeError(t, ` Yaml.Parse('a: [1')`)
+	common.AssertCodeError(t, ` Yaml.Parse('a: 1\n---\nb: 2')`)
+}
+
+func TestModule_Yaml_Stringify(t *testing.T) {
+	common.AssertCode(t, ` Yaml.Stringify({b=1, a=[2.5, 'x', true, Yaml.Null]})`, "b: 1\na:\n  - 2.5\n  - x\n  - true\n  - null\n")
+	common.AssertCode(t, ` Yaml.Stringify({b='1', a='yes'}, {sortKeys=true})`, "a: \"yes\"\nb: \"1\"\n")
+	common.AssertCode(t, ` Yaml.Stringify(['n', 'Off', '1:20', '1:2:3.5', 'yesno'])`, "- \"n\"\n- \"Off\"\n- \"1:20\"\n- \"1:2:3.5\"\n- yesno\n")
+	common.AssertCode(t, ` Yaml.Parse(Yaml.Stringify({a='on'}))['a']`, `on`)
+	common.AssertCode(t, ` Yaml.Stringify({a={b=1}}, {indent=4})`, "a:\n    b: 1\n")
+	common.AssertCode(t, ` Yaml.Stringify('a\nb')`, "|-\n  a\n  b\n")
+	common.AssertCode(t, ` v := Yaml.Parse('a:\n  - b: 1\n    c: [x]\n'); Yaml.Stringify(v)`, "a:\n  - b: 1\n    c:\n      - x\n")
+	common.AssertCodeError(t, ` Yaml.Stringify(fn(){})`)
+	common.AssertCodeError(t, ` Yaml.Stringify(1, {foo=1})`)
+}
+
+func TestModule_Yaml_Documents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.yaml")
+	os.WriteFile(path, []byte("kind: A\n---\n---\nkind: B\n---\n"), 0o644)
+
+	common.AssertCode(t, ` Yaml.Documents('`+path+`') | map (d: d['kind']) | List`, `['A', 'B']`)
+	common.AssertCode(t, ` Yaml.Documents('`+path+`') | take 1 | count`, `1`)
+	common.AssertCode(t, ` [{a=1}, [2]] | Yaml.WriteDocuments '`+dir+`/b.yaml'`, `2`)
+	common.AssertCode(t, ` File.Read('`+dir+`/b.yaml')`, "a: 1\n---\n- 2\n")
+	common.AssertCode(t, ` Yaml.Documents('`+dir+`/b.yaml') | List`, `[{a=1}, [2]]`)
+
+	os.WriteFile(path, []byte("a: 1\n---\na: [\n"), 0o644)
+	common.AssertCode(t, ` Yaml.Documents('`+path+`') | take 1 | count`, `1`)
+	common.AssertCodeError(t, ` Yaml.Documents('`+path+`') | List`)
+	common.AssertCodeError(t, ` Yaml.Documents('`+dir+`/missing.yaml') | List`)
+}
@@ -0,0 +1,384 @@
+package object
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var PatternId = TypeIdentifier("Pattern")
+var PatternTypeObj = NewPatternType()
+
+var PatternMatchId = TypeIdentifier("PatternMatch")
+var PatternMatchTypeObj = NewPatternMatchType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type PatternType struct {
+	*BaseObjectType
+}
+
+func NewPatternType() *PatternType {
+	t := &PatternType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			PatternId,
+		),
+	}
+
+	t.AddMethod(Pattern_Match)
+	t.AddMethod(Pattern_Find)
+	t.AddMethod(Pattern_FindAll)
+	t.AddMethod(Pattern_Replace)
+	t.AddMethod(Pattern_Split)
+	t.AddMethod(Pattern_Names)
+
+	return t
+}
+
+func (o *PatternType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'Pattern' manually, use 'Regex.Compile'"))
+}
+
+// Convert compiles the pattern, as in `Pattern('\d+')`.
+func (o *PatternType) Convert(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *Pattern:
+		return obj
+	case *String:
+		return Module_Regex_Compile.Call(scope, obj)
+	}
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Pattern'", obj.TypeId()))
+}
+
+type PatternMatchType struct {
+	*BaseObjectType
+}
+
+func NewPatternMatchType() *PatternMatchType {
+	t := &PatternMatchType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			PatternMatchId,
+		),
+	}
+
+	t.AddMethod(PatternMatch_Group)
+
+	return t
+}
+
+func (o *PatternMatchType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'PatternMatch' manually"))
+}
+
+func (o *PatternMatchType) Convert(scope *Scope, obj Object) Object {
+	return scope.Interrupt(Raise("cannot convert '%s' to 'PatternMatch'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// Pattern is a compiled regular expression, with the syntax of Go's regexp.
+type Pattern struct {
+	*BaseObject
+	re *regexp.Regexp
+}
+
+func NewPattern(re *regexp.Regexp) *Pattern {
+	r := &Pattern{
+		BaseObject: NewBaseObject(PatternTypeObj),
+		re:         re,
+	}
+	r.SetProperty("pattern", NewString(re.String()))
+	return r
+}
+
+func (o *Pattern) AsBool() bool {
+	return true
+}
+
+func (o *Pattern) AsString() string {
+	return fmt.Sprintf("<pattern '%s'>", o.re.String())
+}
+
+func (o *Pattern) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Pattern) AsInterface() any {
+	return o.AsString()
+}
+
+// PatternMatch is a match of a regex. The properties are `text`, the matched
+// text, `start` and `end`, its position in characters, `groups`, the list
+// of the texts of the groups, and `named`, a dict with the named groups.
+// Groups that did not participate in the match are empty strings.
+type PatternMatch struct {
+	*BaseObject
+	groups []string
+	names  []string
+}
+
+// Returns the match of the submatch indices, where the positions are given in
+// characters, counted from the start of the text up to the offset in bytes.
+func newPatternMatch(re *regexp.Regexp, text string, loc []int, runeOffset, byteOffset int) *PatternMatch {
+	m := &PatternMatch{
+		BaseObject: NewBaseObject(PatternMatchTypeObj),
+		names:      re.SubexpNames(),
+	}
+
+	for i := 0; i < len(loc); i += 2 {
+		group := ""
+		if loc[i] >= 0 {
+			group = text[loc[i]:loc[i+1]]
+		}
+		m.groups = append(m.groups, group)
+	}
+
+	start := runeOffset + utf8.RuneCountInString(text[byteOffset:loc[0]])
+	m.SetProperty("text", NewString(m.groups[0]))
+	m.SetProperty("start", NewNumber(float64(start)))
+	m.SetProperty("end", NewNumber(float64(start+utf8.RuneCountInString(m.groups[0]))))
+
+	groups := make([]Object, len(m.groups)-1)
+	named := NewDict(map[string]Object{})
+	for i, group := range m.groups[1:] {
+		groups[i] = NewString(group)
+		if name := m.names[i+1]; name != "" {
+			named.Set(name, groups[i])
+		}
+	}
+	m.SetProperty("groups", NewList(groups...))
+	m.SetProperty("named", named)
+	return m
+}
+
+func (o *PatternMatch) AsBool() bool {
+	return true
+}
+
+func (o *PatternMatch) AsString() string {
+	return o.groups[0]
+}
+
+func (o *PatternMatch) AsRepr() string {
+	return NewString(o.groups[0]).AsRepr()
+}
+
+func (o *PatternMatch) AsInterface() any {
+	return o.AsString()
+}
+
+// Keeps the last patterns compiled from strings, so methods like
+// `String.Matches` can be called for every line without compiling the pattern
+// every time.
+var regexCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: map[string]*regexp.Regexp{}}
+
+// Returns the regex of a Pattern object or a pattern string.
+func regexOf(scope *Scope, obj Object) (*regexp.Regexp, Object) {
+	switch obj := obj.(type) {
+	case *Pattern:
+		return obj.re, nil
+	case *String:
+		regexCache.Lock()
+		defer regexCache.Unlock()
+		if re, ok := regexCache.patterns[obj.Value]; ok {
+			return re, nil
+		}
+
+		re, err := regexp.Compile(obj.Value)
+		if err != nil {
+			return nil, scope.Interrupt(Raise("invalid regex: %s", err.Error()))
+		}
+		if len(regexCache.patterns) >= 256 {
+			clear(regexCache.patterns)
+		}
+		regexCache.patterns[obj.Value] = re
+		return re, nil
+	}
+	return nil, scope.Interrupt(Raise("expected a pattern or a string, got '%s'", obj.TypeId()))
+}
+
+// Returns the text with the first n matches replaced, or all if n is negative.
+// The replacement is a string, where `$1` and `${name}` are replaced by the
+// groups, or a function receiving the match and returning its replacement.
+func regexReplace(scope *Scope, re *regexp.Regexp, text string, replacement Object, n int) Object {
+	b := strings.Builder{}
+	last, runes := 0, 0
+	for _, loc := range re.FindAllStringSubmatchIndex(text, n) {
+		b.WriteString(text[last:loc[0]])
+
+		switch replacement.(type) {
+		case *String:
+			b.Write(re.ExpandString(nil, replacement.AsString(), text, loc))
+		default:
+			m := newPatternMatch(re, text, loc, runes, last)
+			ret := scope.Eval().Call(scope, replacement, []Object{m})
+			if isRaise(ret) {
+				return ret
+			}
+			b.WriteString(ret.AsString())
+		}
+
+		runes += utf8.RuneCountInString(text[last:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return NewString(b.String())
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Pattern_Match = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		return NewBoolean(this.re.MatchString(args[1].AsString()))
+	},
+	`Match`,
+	`Returns true if the regex matches any part of the text. Use '^' and '$' to match the whole text.`,
+	P("this", V.Type(PatternId)),
+	P("text", V.Type(StringId)),
+)
+
+var Pattern_Find = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		text := args[1].AsString()
+		loc := this.re.FindStringSubmatchIndex(text)
+		if loc == nil {
+			return NewMaybe(NewErrorFromString("no match for '" + this.re.String() + "'"))
+		}
+		return NewMaybe(newPatternMatch(this.re, text, loc, 0, 0))
+	},
+	`Find`,
+	`Returns the first match in the text as a Maybe.`,
+	P("this", V.Type(PatternId)),
+	P("text", V.Type(StringId)),
+)
+
+var Pattern_FindAll = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		text := args[1].AsString()
+		locs := this.re.FindAllStringSubmatchIndex(text, -1)
+
+		i, runes, bytes := 0, 0, 0
+		return NewInternalStream(func(s *Scope) Object {
+			if i >= len(locs) {
+				return nil
+			}
+			loc := locs[i]
+			i++
+
+			m := newPatternMatch(this.re, text, loc, runes, bytes)
+			runes += utf8.RuneCountInString(text[bytes:loc[1]])
+			bytes = loc[1]
+			return YieldWith(m)
+		}, scope)
+	},
+	`FindAll`,
+	`Returns a stream of the matches in the text, which do not overlap.`,
+	P("this", V.Type(PatternId)),
+	P("text", V.Type(StringId)),
+)
+
+var Pattern_Replace = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		n := -1
+		if len(args) > 3 {
+			num, ok := args[3].(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("expected a number of replacements, got '%s'", args[3].TypeId()))
+			}
+			n = int(num.Value)
+		}
+		return regexReplace(scope, this.re, args[1].AsString(), args[2], n)
+	},
+	`Replace`,
+	"Replaces the matches in the text. The replacement is a string, where `$1` and `${name}` are replaced by the groups, or a function receiving the match and returning its replacement. Replaces all matches, unless the number of replacements is given.",
+	P("this", V.Type(PatternId)),
+	P("text", V.Type(StringId)),
+	P("replacement"),
+	P("n"),
+)
+
+var Pattern_Split = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		n := -1
+		if len(args) > 2 {
+			num, ok := args[2].(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("expected a number of parts, got '%s'", args[2].TypeId()))
+			}
+			n = int(num.Value)
+		}
+
+		parts := this.re.Split(args[1].AsString(), n)
+		objs := make([]Object, len(parts))
+		for i, part := range parts {
+			objs[i] = NewString(part)
+		}
+		return NewList(objs...)
+	},
+	`Split`,
+	`Splits the text around the matches, returning at most n parts if given.`,
+	P("this", V.Type(PatternId)),
+	P("text", V.Type(StringId)),
+	P("n"),
+)
+
+var Pattern_Names = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Pattern)
+		var names []Object
+		for _, name := range this.re.SubexpNames()[1:] {
+			if name != "" {
+				names = append(names, NewString(name))
+			}
+		}
+		return NewList(names...)
+	},
+	`Names`,
+	`Returns the list of the names of the named groups.`,
+	P("this", V.Type(PatternId)),
+)
+
+var PatternMatch_Group = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*PatternMatch)
+		switch group := args[1].(type) {
+		case *Number:
+			i := int(group.Value)
+			if i < 0 || i >= len(this.groups) {
+				return scope.Interrupt(Raise("group %d not found", i))
+			}
+			return NewString(this.groups[i])
+
+		default:
+			name := group.AsString()
+			for i, n := range this.names {
+				if n == name && name != "" {
+					return NewString(this.groups[i])
+				}
+			}
+			return scope.Interrupt(Raise("group '%s' not found", name))
+		}
+	},
+	`Group`,
+	`Returns the text of the group, given by its number or name. The group 0 is the whole match.`,
+	P("this", V.Type(PatternMatchId)),
+	P("group"),
+)
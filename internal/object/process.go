@@ -0,0 +1,454 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+var ProcessId = TypeIdentifier("Process")
+var ProcessTypeObj = NewProcessType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type ProcessType struct {
+	*BaseObjectType
+}
+
+func NewProcessType() *ProcessType {
+	t := &ProcessType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			ProcessId,
+		),
+	}
+
+	t.AddMethod(Process_Wait)
+	t.AddMethod(Process_Code)
+	t.AddMethod(Process_Ok)
+	t.AddMethod(Process_Done)
+	t.AddMethod(Process_Pid)
+	t.AddMethod(Process_Stdout)
+	t.AddMethod(Process_Stderr)
+	t.AddMethod(Process_Output)
+	t.AddMethod(Process_Kill)
+
+	return t
+}
+
+func (o *ProcessType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'Process' manually, use 'Exec.Start'"))
+}
+
+func (o *ProcessType) Convert(scope *Scope, obj Object) Object {
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Process'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// Process is an external command started by the Exec module. Its output can
+// be read as streams of lines while it runs. The unread stdout is buffered up
+// to a limit, after which the process blocks writing, like with a pipe, until
+// it is read or waited for.
+type Process struct {
+	*BaseObject
+	command string
+	cmd     *exec.Cmd
+	cancel  context.CancelFunc
+	stdout  *processOutput
+	stderr  *processOutput
+	done    chan struct{}
+	fed     chan struct{} // closed when the stdin stream is written, if any
+	code    int
+	err     error  // error other than a non-zero exit code, like not finding the command
+	feedErr Object // raise of the stream given as stdin, or of writing it, set before fed is closed
+}
+
+// StartProcess starts the command, returning a raise if it cannot be started.
+// The process is waited for in the background.
+func StartProcess(scope *Scope, spec *processSpec) (*Process, Object) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if spec.timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), spec.timeout)
+	}
+
+	cmd := exec.CommandContext(ctx, spec.name, spec.args...)
+	cmd.Dir = spec.dir
+	cmd.Env = spec.env
+	p := &Process{
+		BaseObject: NewBaseObject(ProcessTypeObj),
+		command:    strings.Join(append([]string{spec.name}, spec.args...), " "),
+		cmd:        cmd,
+		cancel:     cancel,
+		stdout:     newProcessOutput(false),
+		stderr:     newProcessOutput(true),
+		done:       make(chan struct{}),
+		fed:        make(chan struct{}),
+	}
+	cmd.Stdout = p.stdout
+	cmd.Stderr = p.stderr
+
+	var feed func()
+	switch stdin := spec.stdin.(type) {
+	case nil:
+	case *Stream:
+		feed = p.feeder(scope, stdin)
+	default:
+		cmd.Stdin = strings.NewReader(stdin.AsString())
+	}
+
+	var err error
+	scope.Blocking(func() {
+		err = cmd.Start()
+	})
+	if err != nil {
+		cancel()
+		return nil, scope.Interrupt(Raise("could not start '%s': %s", spec.name, err.Error()))
+	}
+	if feed != nil {
+		go feed()
+	} else {
+		close(p.fed)
+	}
+
+	go func() {
+		err := cmd.Wait()
+		cancel()
+
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() == context.DeadlineExceeded:
+			p.code, p.err = -1, fmt.Errorf("timed out after %s", spec.timeout)
+		case errors.As(err, &exitErr):
+			p.code = exitErr.ExitCode()
+		case err != nil:
+			p.code, p.err = -1, err
+		}
+
+		p.stdout.close()
+		p.stderr.close()
+		close(p.done)
+	}()
+
+	return p, nil
+}
+
+// Returns the function writing the elements of the stream to the stdin of
+// the process, one per line. It runs in its own goroutine, pulling the stream
+// while holding the interpreter lock. Errors writing are raised by Check, but
+// the process exiting before reading everything is not an error.
+func (o *Process) feeder(scope *Scope, stream *Stream) func() {
+	w, err := o.cmd.StdinPipe()
+	if err != nil {
+		raise := scope.Interrupt(Raise("could not write to the stdin of '%s': %s", o.command, err.Error()))
+		return func() {
+			o.feedErr = raise
+			close(o.fed)
+		}
+	}
+
+	feedScope := scope.New()
+	return func() {
+		defer close(o.fed)
+		feedScope.Acquire()
+		defer feedScope.Release()
+		defer w.Close()
+
+		ret := stream.Resolve(func(value Object) Object {
+			line := streamValue(stream, value).AsString() + "\n"
+
+			var err error
+			feedScope.Blocking(func() {
+				_, err = w.Write([]byte(line))
+			})
+			if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+				// The process stopped reading
+				return stream.Close(feedScope)
+			}
+			if err != nil {
+				return feedScope.Interrupt(Raise("could not write to the stdin of '%s': %s", o.command, err.Error()))
+			}
+			return nil
+		})
+		if isRaise(ret) {
+			o.feedErr = ret
+			o.Kill()
+		}
+	}
+}
+
+// Wait blocks until the process finishes and its stdin stream is written,
+// releasing the interpreter lock meanwhile. The output is no longer limited,
+// so the process does not block writing it.
+func (o *Process) Wait(scope *Scope) {
+	o.stdout.unbound()
+	o.stderr.unbound()
+	scope.Blocking(func() {
+		<-o.done
+		<-o.fed
+	})
+}
+
+func (o *Process) IsDone() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check waits for the process, returning a raise if it failed, with the last
+// line of its stderr.
+func (o *Process) Check(scope *Scope) Object {
+	o.Wait(scope)
+	switch {
+	case o.feedErr != nil:
+		return o.feedErr
+	case o.err != nil:
+		return scope.Interrupt(Raise("command '%s' failed: %s", o.command, o.err.Error()))
+	case o.code != 0:
+		msg := fmt.Sprintf("command '%s' exited with code %d", o.command, o.code)
+		if line := o.stderr.lastLine(); line != "" {
+			msg += ": " + line
+		}
+		return scope.Interrupt(Raise("%s", msg))
+	}
+	return nil
+}
+
+func (o *Process) Kill() {
+	o.cancel()
+	o.stdout.unbound()
+	o.stderr.unbound()
+}
+
+func (o *Process) AsBool() bool {
+	return true
+}
+
+func (o *Process) AsString() string {
+	if o.IsDone() {
+		return fmt.Sprintf("<process '%s' exited %d>", o.command, o.code)
+	}
+	return fmt.Sprintf("<process '%s' running>", o.command)
+}
+
+func (o *Process) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Process) AsInterface() any {
+	return o.AsString()
+}
+
+// The size of the unread output kept by a process before it blocks writing,
+// or drops the oldest bytes of stderr.
+const processOutputLimit = 64 * 1024
+
+// processOutput holds the output written by the process and not read yet,
+// waking up the streams waiting for more and the writer waiting for room.
+type processOutput struct {
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	data      []byte
+	closed    bool
+	lossy     bool // drops the oldest bytes instead of blocking the writer
+	unbounded bool // keeps everything, once the process is waited for or killed
+}
+
+func newProcessOutput(lossy bool) *processOutput {
+	p := &processOutput{lossy: lossy}
+	p.cond = sync.NewCond(&p.mutex)
+	return p
+}
+
+func (p *processOutput) Write(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for !p.lossy && !p.unbounded && len(p.data) >= processOutputLimit {
+		p.cond.Wait()
+	}
+	p.data = append(p.data, b...)
+	if p.lossy && !p.unbounded && len(p.data) > processOutputLimit {
+		p.data = p.data[len(p.data)-processOutputLimit:]
+	}
+	p.cond.Broadcast()
+	return len(b), nil
+}
+
+func (p *processOutput) unbound() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.unbounded = true
+	p.cond.Broadcast()
+}
+
+func (p *processOutput) close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+func (p *processOutput) String() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return string(p.data)
+}
+
+// Waits for the next line, returning it without the line break and dropping
+// it from the output. Returns false if there are no more lines.
+func (p *processOutput) line() (string, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for {
+		if i := bytes.IndexByte(p.data, '\n'); i >= 0 {
+			line := strings.TrimSuffix(string(p.data[:i]), "\r")
+			p.data = p.data[i+1:]
+			p.cond.Broadcast()
+			return line, true
+		}
+		if p.closed {
+			if len(p.data) > 0 {
+				line := string(p.data)
+				p.data = nil
+				return line, true
+			}
+			return "", false
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *processOutput) lastLine() string {
+	lines := strings.Split(strings.TrimSpace(p.String()), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Returns a stream of the unread lines of the output, waiting for the process
+// to write them.
+func (p *processOutput) stream(scope *Scope) *Stream {
+	return NewInternalStream(func(s *Scope) Object {
+		var line string
+		var ok bool
+		s.Blocking(func() {
+			line, ok = p.line()
+		})
+		if !ok {
+			return nil
+		}
+		return YieldWith(NewString(line))
+	}, scope)
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Process_Wait = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		if err := this.Check(scope); err != nil {
+			return err
+		}
+		return this
+	},
+	`Wait`,
+	`Waits for the process to finish. Raises if it exits with a non-zero code.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Code = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		this.Wait(scope)
+		return NewNumber(float64(this.code))
+	},
+	`Code`,
+	`Waits for the process to finish, returning its exit code. The code is -1 if the process was killed or timed out.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Ok = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		this.Wait(scope)
+		return NewBoolean(this.code == 0 && this.err == nil && this.feedErr == nil)
+	},
+	`Ok`,
+	`Waits for the process to finish, returning true if it succeeded.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Done = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		return NewBoolean(this.IsDone())
+	},
+	`Done`,
+	`Returns true if the process finished.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Pid = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		return NewNumber(float64(this.cmd.Process.Pid))
+	},
+	`Pid`,
+	`Returns the process id.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Stdout = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		return this.stdout.stream(scope)
+	},
+	`Stdout`,
+	`Returns a stream of the lines written by the process to stdout and not read yet, as the process writes them.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Stderr = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		return this.stderr.stream(scope)
+	},
+	`Stderr`,
+	`Returns a stream of the lines written by the process to stderr and not read yet, as the process writes them. While the process runs, only the last 64KB are kept.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Output = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		this.Wait(scope)
+		return NewString(this.stdout.String())
+	},
+	`Output`,
+	`Waits for the process to finish, returning what it wrote to stdout and was not read yet.`,
+	P("this", V.Type(ProcessId)),
+)
+
+var Process_Kill = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Process)
+		this.Kill()
+		return this
+	},
+	`Kill`,
+	`Kills the process.`,
+	P("this", V.Type(ProcessId)),
+)
@@ -2,6 +2,9 @@ package object
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sync"
 
 	"github.com/renatopp/langtools/utils"
 	"github.com/renatopp/pipelang/internal/ast"
@@ -27,6 +30,16 @@ type Scope struct {
 	runner       Runner
 	eval         Evaluator
 	activeRecord ActiveRecord
+	nodeRecords  map[ast.Node]ActiveRecord
+	deferred     *[]func() Object // calls registered by `defer`, nil if not a function scope
+	clock        Clock
+	stderr       io.Writer
+	tracer       *PipeTracer // nil unless the pipes are traced
+	args         []string    // arguments of the script
+	exit         func(code int)
+	lock         *sync.Mutex     // the interpreter lock, shared by the scopes of a runtime
+	done         <-chan struct{} // closed to cancel the evaluation, nil if it cannot be cancelled
+	peers        *peerWaits      // the goroutines of the runtime, to find deadlocks
 }
 
 func NewScope(r Runner) *Scope {
@@ -36,6 +49,11 @@ func NewScope(r Runner) *Scope {
 		parent: nil,
 		runner: r,
 		eval:   nil,
+		clock:  RealClock{},
+		stderr: os.Stderr,
+		exit:   os.Exit,
+		lock:   &sync.Mutex{},
+		peers:  newPeerWaits(),
 	}
 }
 
@@ -46,6 +64,14 @@ func (s *Scope) New() *Scope {
 		parent: s,
 		runner: s.runner,
 		eval:   s.eval,
+		clock:  s.clock,
+		stderr: s.stderr,
+		tracer: s.tracer,
+		args:   s.args,
+		exit:   s.exit,
+		lock:   s.lock,
+		done:   s.done,
+		peers:  s.peers,
 	}
 }
 
@@ -132,6 +158,109 @@ func (s *Scope) WithEval(eval Evaluator) *Scope {
 	return s
 }
 
+// WithClock replaces the clock of the scope. Scopes created afterwards from
+// this one share the same clock.
+func (s *Scope) WithClock(clock Clock) *Scope {
+	s.clock = clock
+	return s
+}
+
+func (s *Scope) Clock() Clock {
+	return s.clock
+}
+
+// WithStderr replaces the writer used for diagnostics, like progress bars.
+// Scopes created afterwards from this one share the same writer.
+func (s *Scope) WithStderr(w io.Writer) *Scope {
+	s.stderr = w
+	return s
+}
+
+func (s *Scope) Stderr() io.Writer {
+	return s.stderr
+}
+
+// WithTracer enables the tracing of the pipe calls evaluated in the scope and
+// in the scopes created afterwards from this one.
+func (s *Scope) WithTracer(tracer *PipeTracer) *Scope {
+	s.tracer = tracer
+	return s
+}
+
+func (s *Scope) Tracer() *PipeTracer {
+	return s.tracer
+}
+
+// WithArgs sets the command line arguments of the script, shared by the
+// scopes created afterwards from this one.
+func (s *Scope) WithArgs(args []string) *Scope {
+	s.args = args
+	return s
+}
+
+func (s *Scope) Args() []string {
+	return s.args
+}
+
+// WithExit replaces the function called by `Os.Exit`, which is os.Exit by
+// default.
+func (s *Scope) WithExit(exit func(code int)) *Scope {
+	s.exit = exit
+	return s
+}
+
+func (s *Scope) Exit(code int) {
+	s.exit(code)
+}
+
+// WithCancel stops the evaluation in the scope, and in the scopes created from
+// it, once done is closed. Calls and waits, like sleeping or receiving from a
+// channel, raise from then on.
+func (s *Scope) WithCancel(done <-chan struct{}) *Scope {
+	s.done = done
+	return s
+}
+
+// Done returns the channel closed when the evaluation is cancelled, or nil if
+// it cannot be cancelled.
+func (s *Scope) Done() <-chan struct{} {
+	return s.done
+}
+
+// Cancelled raises if the evaluation in the scope was cancelled, returning
+// nil otherwise.
+func (s *Scope) Cancelled() Object {
+	select {
+	case <-s.done:
+		return s.Interrupt(Raise("evaluation cancelled"))
+	default:
+		return nil
+	}
+}
+
+// Acquire takes the interpreter lock. Objects and scopes are not safe for
+// concurrent use, so only the goroutine holding the lock may evaluate code.
+// Each goroutine evaluating code acquires it once, when it starts.
+func (s *Scope) Acquire() {
+	s.peers.add(1, 0)
+	s.lock.Lock()
+}
+
+// Release gives the interpreter lock back, when the goroutine is done.
+func (s *Scope) Release() {
+	s.lock.Unlock()
+	s.peers.add(-1, 0)
+}
+
+// Blocking releases the interpreter lock while fn runs, letting other
+// goroutines evaluate code. Used around calls that wait, like sleeping or
+// reading from the disk. fn must not touch any object.
+func (s *Scope) Blocking(fn func()) {
+	s.lock.Unlock()
+	defer s.lock.Lock()
+	fn()
+}
+
 func (s *Scope) SetActiveRecord(ar ActiveRecord) {
 	s.activeRecord = ar
 }
@@ -140,6 +269,63 @@ func (s *Scope) ActiveRecord() ActiveRecord {
 	return s.activeRecord
 }
 
+// SetNodeRecord stores the state of an expression paused by a yield, to be
+// resumed when the expression is evaluated again.
+func (s *Scope) SetNodeRecord(node ast.Node, ar ActiveRecord) {
+	if s.nodeRecords == nil {
+		s.nodeRecords = make(map[ast.Node]ActiveRecord)
+	}
+	s.nodeRecords[node] = ar
+}
+
+// NodeRecord returns and clears the state stored for the expression, nil if
+// it was not paused.
+func (s *Scope) NodeRecord(node ast.Node) ActiveRecord {
+	ar, ok := s.nodeRecords[node]
+	if !ok {
+		return nil
+	}
+	delete(s.nodeRecords, node)
+	return ar
+}
+
+// NewDeferFrame marks the scope as the scope of a function, where the `defer`
+// calls of its body are registered.
+func (s *Scope) NewDeferFrame() {
+	s.deferred = &[]func() Object{}
+}
+
+// Defer registers a call to run when the nearest function scope finishes.
+// Returns false if there is no function scope.
+func (s *Scope) Defer(fn func() Object) bool {
+	for cur := s; cur != nil; cur = cur.parent {
+		if cur.deferred != nil {
+			*cur.deferred = append(*cur.deferred, fn)
+			return true
+		}
+	}
+	return false
+}
+
+// RunDeferred runs the deferred calls of the scope in reverse order. All calls
+// run even if some of them raise, and the first raise is returned.
+func (s *Scope) RunDeferred() Object {
+	if s.deferred == nil {
+		return nil
+	}
+
+	var err Object
+	calls := *s.deferred
+	*s.deferred = nil
+	for i := len(calls) - 1; i >= 0; i-- {
+		ret := calls[i]()
+		if isRaise(ret) && err == nil {
+			err = ret
+		}
+	}
+	return err
+}
+
 func (s *Scope) Print(name string) {
 	parent := s
 	i := 0
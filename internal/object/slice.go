@@ -0,0 +1,162 @@
+package object
+
+import (
+	"fmt"
+)
+
+var SliceId = TypeIdentifier("Slice")
+var SliceTypeObj = NewSliceType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type SliceType struct {
+	*BaseObjectType
+}
+
+func NewSliceType() *SliceType {
+	return &SliceType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			SliceId,
+		),
+	}
+}
+
+func (o *SliceType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'Slice' manually"))
+}
+
+func (o *SliceType) Convert(scope *Scope, obj Object) Object {
+	return scope.Interrupt(Raise("type 'Slice' does not support conversion"))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+// Slice represents the `start:stop:step` inside an index, like `a[1:3]`. Nil
+// parts are the ones omitted.
+type Slice struct {
+	*BaseObject
+	Start *Number
+	Stop  *Number
+	Step  *Number
+}
+
+func NewSlice(start, stop, step *Number) *Slice {
+	return &Slice{
+		BaseObject: NewBaseObject(SliceTypeObj),
+		Start:      start,
+		Stop:       stop,
+		Step:       step,
+	}
+}
+
+// Indices resolves the slice against a sequence of the given length, returning
+// the indices selected by the slice in order. Negative values count from the
+// end of the sequence and out of bounds values are clamped, like in Python.
+func (o *Slice) Indices(scope *Scope, length int) ([]int, Object) {
+	step := 1
+	if o.Step != nil {
+		step = int(o.Step.Value)
+	}
+	if step == 0 {
+		return nil, scope.Interrupt(Raise("slice step cannot be zero"))
+	}
+
+	indices := []int{}
+	if step > 0 {
+		start := o.resolve(o.Start, 0, length, step)
+		stop := o.resolve(o.Stop, length, length, step)
+		for i := start; i < stop; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		start := o.resolve(o.Start, length-1, length, step)
+		stop := o.resolve(o.Stop, -1, length, step)
+		for i := start; i > stop; i += step {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices, nil
+}
+
+// Bounds resolves a contiguous slice against a sequence of the given length,
+// returning the range [start, stop).
+func (o *Slice) Bounds(length int) (int, int) {
+	start := o.resolve(o.Start, 0, length, 1)
+	stop := o.resolve(o.Stop, length, length, 1)
+	return start, max(start, stop)
+}
+
+func (o *Slice) resolve(n *Number, def, length, step int) int {
+	if n == nil {
+		return def
+	}
+
+	i := int(n.Value)
+	if i < 0 {
+		i += length
+	}
+
+	switch {
+	case i < 0 && step < 0:
+		return -1
+	case i < 0:
+		return 0
+	case i >= length && step < 0:
+		return length - 1
+	case i >= length:
+		return length
+	}
+	return i
+}
+
+// IsContiguous returns true if the slice selects a contiguous range, going
+// forward.
+func (o *Slice) IsContiguous() bool {
+	return o.Step == nil || o.Step.Value == 1
+}
+
+func (o *Slice) AsBool() bool {
+	return true
+}
+
+func (o *Slice) AsString() string {
+	part := func(n *Number) string {
+		if n == nil {
+			return ""
+		}
+		return n.AsString()
+	}
+
+	if o.Step == nil {
+		return fmt.Sprintf("%s:%s", part(o.Start), part(o.Stop))
+	}
+	return fmt.Sprintf("%s:%s:%s", part(o.Start), part(o.Stop), part(o.Step))
+}
+
+func (o *Slice) AsInterface() any {
+	return o.AsString()
+}
+
+func (o *Slice) AsRepr() string {
+	return o.AsString()
+}
+
+// selectSlice returns the elements selected by the slice.
+func selectSlice[T any](scope *Scope, slice *Slice, elements []T) ([]T, Object) {
+	indices, err := slice.Indices(scope, len(elements))
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]T, len(indices))
+	for i, idx := range indices {
+		selected[i] = elements[idx]
+	}
+	return selected, nil
+}
@@ -20,7 +20,10 @@ func NewStreamType() *StreamType {
 	}
 
 	t.AddMethod(Stream_Next)
+	t.AddMethod(Stream_Send)
+	t.AddMethod(Stream_Throw)
 	t.AddMethod(Stream_Finished)
+	t.AddMethod(Stream_Close)
 
 	return t
 }
@@ -40,6 +43,9 @@ func (o *StreamType) Convert(scope *Scope, obj Object) Object {
 	case ListId:
 		return List_Elements.Call(scope, obj)
 
+	case ChannelId:
+		return obj.(*Channel).Stream(scope)
+
 	default:
 		return NewInternalStream(func(s *Scope) Object {
 			return YieldWith(obj)
@@ -59,8 +65,21 @@ type Stream struct {
 	Fn         *Function
 	InternalFn func(*Scope) Object
 	iteration  *StreamIteration // used only to indicate the evaluator to call the stream
+	Indexed    bool             // if the stream yields (value, index) tuples, like the streams of lists
+
+	// Generator state, used by the evaluator to resume a paused `yield`
+	Suspended bool    // true while the generator is paused in a yield
+	Sent      Object  // value sent by `Send`, returned by the paused yield
+	Thrown    *Error  // error sent by `Throw`, raised by the paused yield
+	Delegate  *Stream // stream being consumed by a `yield from`
+
+	closed  bool
+	onClose []func(*Scope) Object // cleanup hooks, run in reverse order by Close
 }
 
+// StreamKey is the scope key holding the stream of a running generator.
+const StreamKey = "$stream"
+
 // Scope is the fixed scope of the function
 func NewStream(fn *Function, scope *Scope) *Stream {
 	s := &Stream{
@@ -72,6 +91,7 @@ func NewStream(fn *Function, scope *Scope) *Stream {
 	s.iteration = &StreamIteration{
 		Stream: s,
 	}
+	scope.SetLocal(StreamKey, s)
 
 	return s
 }
@@ -102,11 +122,68 @@ func (o *Stream) Resolve(fn func(Object) Object) Object {
 		value := maybe.(*Maybe).Value
 		ret := fn(value)
 		if isRaise(ret) {
-			return ret
+			return o.CloseWith(o.Scope, ret)
 		}
 	}
 }
 
+// OnClose registers a cleanup hook, called when the stream finishes, raises or
+// is closed by its consumer.
+func (o *Stream) OnClose(fn func(*Scope) Object) *Stream {
+	o.onClose = append(o.onClose, fn)
+	return o
+}
+
+// WithUpstream closes the given streams together with this one. Used by the
+// pipe stages to propagate the close to the streams they consume.
+func (o *Stream) WithUpstream(upstream ...*Stream) *Stream {
+	for _, s := range upstream {
+		o.OnClose(s.Close)
+	}
+	return o
+}
+
+// Close finishes the stream, running the deferred calls of the generator and
+// the cleanup hooks. Closing a stream more than once has no effect. Returns
+// the first raise of the cleanup, if any.
+func (o *Stream) Close(scope *Scope) Object {
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	o.Finished = true
+	o.Suspended = false
+
+	var err Object
+	if o.Delegate != nil {
+		err = o.Delegate.Close(scope)
+		o.Delegate = nil
+	}
+
+	if o.Fn != nil {
+		if ret := o.Scope.RunDeferred(); ret != nil && err == nil {
+			err = ret
+		}
+	}
+
+	for i := len(o.onClose) - 1; i >= 0; i-- {
+		if ret := o.onClose[i](scope); isRaise(ret) && err == nil {
+			err = ret
+		}
+	}
+	return err
+}
+
+// CloseWith closes the stream and returns the given object, or the raise of
+// the cleanup if the object is not a raise itself.
+func (o *Stream) CloseWith(scope *Scope, obj Object) Object {
+	err := o.Close(scope)
+	if err != nil && !isRaise(obj) {
+		return err
+	}
+	return obj
+}
+
 func (o *Stream) AsBool() bool {
 	return true
 }
@@ -128,33 +205,87 @@ func (o *Stream) AsInterface() any {
 // ----------------------------------------------------------------------------
 var Stream_Next = NewBuiltinFunction("Next", func(scope *Scope, args ...Object) Object {
 	this := args[0].(*Stream)
-	if this.Finished {
+	return this.resume(scope)
+})
+
+var Stream_Send = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Stream)
+		if this.Suspended {
+			this.Sent = args[1]
+		}
+		return this.resume(scope)
+	},
+	`Send`,
+	`Resumes the stream like Next, making the paused yield expression evaluate to the given value. The first call only starts the stream, so the value is discarded.`,
+	P("this", V.Type(StreamId)),
+	P("value"),
+)
+
+var Stream_Throw = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Stream)
+		err, ok := args[1].(*Error)
+		if !ok {
+			err = NewError(args[1])
+		}
+
+		// Streams that are not paused in a yield cannot handle the error
+		if this.Finished || this.Fn == nil || !this.Suspended {
+			return this.CloseWith(scope, scope.Interrupt(RaiseWith(err)))
+		}
+
+		this.Thrown = err
+		return this.resume(scope)
+	},
+	`Throw`,
+	`Raises the error inside the stream, at the paused yield expression. If the stream handles the error, the next yielded value is returned like in Next, otherwise the error is raised.`,
+	P("this", V.Type(StreamId)),
+	P("error"),
+)
+
+var Stream_Finished = NewBuiltinFunction("Finished", func(scope *Scope, args ...Object) Object {
+	this := args[0].(*Stream)
+	return NewBoolean(this.Finished)
+})
+
+var Stream_Close = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Stream)
+		if err := this.Close(scope); err != nil {
+			return err
+		}
+		return this
+	},
+	`Close`,
+	`Finishes the stream, running its deferred calls and closing the streams it consumes. Closing a finished stream has no effect.`,
+	P("this", V.Type(StreamId)),
+)
+
+// Runs the stream until its next yield, returning the yielded value as a
+// Maybe.
+func (o *Stream) resume(scope *Scope) Object {
+	if o.Finished {
 		return NewMaybe(NewErrorFromString("Stream finished"))
 	}
 
 	var ret Object
-	if this.Fn != nil {
-		ret = scope.Eval().RawEval(this.Scope, this.Fn.Body)
+	if o.Fn != nil {
+		ret = scope.Eval().RawEval(o.Scope, o.Fn.Body)
 	} else {
-		ret = this.InternalFn(this.Scope)
+		ret = o.InternalFn(o.Scope)
 	}
 
 	if isRaise(ret) {
-		return ret
+		return o.CloseWith(scope, ret)
 	}
 
 	if t := asYield(ret); t != nil {
 		return NewMaybe(t.Value)
 	}
 
-	this.Finished = true
-	return NewMaybe(NewErrorFromString("Stream finished"))
-})
-
-var Stream_Finished = NewBuiltinFunction("Finished", func(scope *Scope, args ...Object) Object {
-	this := args[0].(*Stream)
-	return NewBoolean(this.Finished)
-})
+	return o.CloseWith(scope, NewMaybe(NewErrorFromString("Stream finished")))
+}
 
 // func streamFinishedError() o.Object {
 // 	return o.NewMaybe(o.NewErrorFromString("Stream finished"))
@@ -60,10 +60,12 @@ func init() {
 	StringTypeObj.AddMethod(String_TrimRight)
 	StringTypeObj.AddMethod(String_Replace)
 	StringTypeObj.AddMethod(String_ReplaceN)
+	StringTypeObj.AddMethod(String_ReplaceRegex)
 	StringTypeObj.AddMethod(String_Sort)
 	StringTypeObj.AddMethod(String_SortFn)
 	StringTypeObj.AddMethod(String_Contains)
 	StringTypeObj.AddMethod(String_ContainsChars)
+	StringTypeObj.AddMethod(String_Matches)
 	StringTypeObj.AddMethod(String_StartsWith)
 	StringTypeObj.AddMethod(String_EndsWith)
 	StringTypeObj.AddMethod(String_IsEmpty)
@@ -867,6 +869,22 @@ var String_ReplaceN = F(
 	P("n", V.Type(NumberId)),
 )
 
+var String_ReplaceRegex = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*String)
+		re, err := regexOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return regexReplace(scope, re, this.Value, args[2], -1)
+	},
+	`ReplaceRegex`,
+	`Replace all matches of the pattern, a string or a compiled pattern. The replacement is a string, where $1 and ${name} are replaced by the groups, or a function receiving the match.`,
+	P("this", V.Type(StringId)),
+	P("pattern"),
+	P("replacement"),
+)
+
 var String_Sort = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*String)
@@ -941,6 +959,21 @@ var String_ContainsChars = F(
 	P("search", V.Type(StringId)),
 )
 
+var String_Matches = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*String)
+		re, err := regexOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewBoolean(re.MatchString(this.Value))
+	},
+	`Matches`,
+	`Check if any part of the string matches the pattern, a string or a compiled pattern.`,
+	P("this", V.Type(StringId)),
+	P("pattern"),
+)
+
 var String_StartsWith = F(
 	func(scope *Scope, args ...Object) Object {
 		this := args[0].(*String)
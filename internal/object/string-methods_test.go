@@ -322,12 +322,26 @@ func TestString_Replace(t *testing.T) {
 	common.AssertCode(t, `'🎁,🎁!ç🎁'.Replace('🎁', '🥸')`, `🥸,🥸!ç🥸`)
 }
 
+func TestString_Matches(t *testing.T) {
+	common.AssertCode(t, "'error: 42'.Matches(r'^error: \\d+$')", `true`)
+	common.AssertCode(t, "'warn'.Matches('^error')", `false`)
+	common.AssertCode(t, "'ERROR'.Matches(Regex.Compile('error', 'i'))", `true`)
+	common.AssertCodeError(t, "'a'.Matches('[')")
+}
+
 func TestString_ReplaceN(t *testing.T) {
 	common.AssertCode(t, `''.ReplaceN('a', 'no', 1)`, ``)
 	common.AssertCode(t, `'a b c a'.ReplaceN('a', 'no', 1)`, `no b c a`)
 	common.AssertCode(t, `'🎁,🎁!ç🎁'.ReplaceN('🎁', '🥸', 1)`, `🥸,🎁!ç🎁`)
 }
 
+func TestString_ReplaceRegex(t *testing.T) {
+	common.AssertCode(t, "'a1 b22'.ReplaceRegex(r'\\d+', '#')", `a# b#`)
+	common.AssertCode(t, "'k=v x=y'.ReplaceRegex(r'(\\w)=(\\w)', '$2=$1')", `v=k y=x`)
+	common.AssertCode(t, "'a1 b22'.ReplaceRegex(Regex.Compile(r'\\d+'), (m: m.text.Size()))", `a1 b2`)
+	common.AssertCodeError(t, "'a'.ReplaceRegex('(', '')")
+}
+
 func TestString_Sort(t *testing.T) {
 	common.AssertCode(t, `''.Sort()`, ``)
 	common.AssertCode(t, `'a c b'.Sort()`, `  abc`)
@@ -67,6 +67,14 @@ func NewString(value string) *String {
 
 func (o *String) OnIndex(scope *Scope, t *Tuple) Object {
 	if len(t.Elements) == 1 {
+		if slice, ok := t.Elements[0].(*Slice); ok {
+			runes, err := selectSlice(scope, slice, []rune(o.Value))
+			if err != nil {
+				return err
+			}
+			return NewString(string(runes))
+		}
+
 		return String_Get.Call(scope, o, t.Elements[0])
 	} else if len(t.Elements) == 2 {
 		return String_Sub.Call(scope, o, t.Elements[0], t.Elements[1])
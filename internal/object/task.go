@@ -0,0 +1,147 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var TaskId = TypeIdentifier("Task")
+var TaskTypeObj = NewTaskType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type TaskType struct {
+	*BaseObjectType
+}
+
+func NewTaskType() *TaskType {
+	t := &TaskType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			TaskId,
+		),
+	}
+
+	t.AddMethod(Task_Wait)
+	t.AddMethod(Task_Done)
+	t.AddMethod(Task_Result)
+
+	return t
+}
+
+func (o *TaskType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'Task' manually, use 'go'"))
+}
+
+func (o *TaskType) Convert(scope *Scope, obj Object) Object {
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Task'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// Task is an expression running in another goroutine, created by `go`.
+type Task struct {
+	*BaseObject
+	done   chan struct{}
+	result *Maybe
+}
+
+func NewTask() *Task {
+	return &Task{
+		BaseObject: NewBaseObject(TaskTypeObj),
+		done:       make(chan struct{}),
+	}
+}
+
+// Finish stores the result of the task, a raise becoming the Maybe error, and
+// wakes up the ones waiting for it.
+func (o *Task) Finish(result Object) {
+	o.result = NewMaybe(result)
+	close(o.done)
+}
+
+// Wait blocks until the task finishes, releasing the interpreter lock
+// meanwhile, and returns its result. Raises if the scope is cancelled first,
+// or if the task can never finish.
+func (o *Task) Wait(scope *Scope) (*Maybe, Object) {
+	_, _, _, err := scope.waitPeers([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.done)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o.result, nil
+}
+
+func (o *Task) IsDone() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *Task) AsBool() bool {
+	return true
+}
+
+func (o *Task) AsString() string {
+	if o.IsDone() {
+		return fmt.Sprintf("<task done: %s>", o.result.AsString())
+	}
+	return "<task running>"
+}
+
+func (o *Task) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Task) AsInterface() any {
+	return o.AsString()
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Task_Wait = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Task)
+		result, err := this.Wait(scope)
+		if err != nil {
+			return err
+		}
+		return result
+	},
+	`Wait`,
+	`Blocks until the task finishes, returning its result as a Maybe. Raises inside the task become the error of the Maybe.`,
+	P("this", V.Type(TaskId)),
+)
+
+var Task_Done = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Task)
+		return NewBoolean(this.IsDone())
+	},
+	`Done`,
+	`Returns true if the task finished.`,
+	P("this", V.Type(TaskId)),
+)
+
+var Task_Result = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Task)
+		if !this.IsDone() {
+			return NewMaybe(NewErrorFromString("task is still running"))
+		}
+		return this.result
+	},
+	`Result`,
+	`Returns the result of the task as a Maybe, without waiting. If the task is still running, the Maybe holds an error.`,
+	P("this", V.Type(TaskId)),
+)
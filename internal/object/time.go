@@ -0,0 +1,695 @@
+package object
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var TimeId = TypeIdentifier("Time")
+var TimeTypeObj = NewTimeType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+//
+// The type also works as the module of the time functions, like `Time.Now()`
+// and `Time.Parse(text)`, which are called without an instance.
+// ----------------------------------------------------------------------------
+type TimeType struct {
+	*BaseObjectType
+}
+
+func NewTimeType() *TimeType {
+	return &TimeType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			TimeId,
+		),
+	}
+}
+
+func init() {
+	TimeTypeObj.AddMethod(Time_Now)
+	TimeTypeObj.AddMethod(Time_Parse)
+	TimeTypeObj.AddMethod(Time_Date)
+	TimeTypeObj.AddMethod(Time_Since)
+	TimeTypeObj.AddMethod(Time_Sleep)
+
+	TimeTypeObj.AddMethod(Time_Format)
+	TimeTypeObj.AddMethod(Time_In)
+	TimeTypeObj.AddMethod(Time_Utc)
+	TimeTypeObj.AddMethod(Time_Local)
+	TimeTypeObj.AddMethod(Time_Zone)
+	TimeTypeObj.AddMethod(Time_Add)
+	TimeTypeObj.AddMethod(Time_AddDate)
+	TimeTypeObj.AddMethod(Time_Sub)
+	TimeTypeObj.AddMethod(Time_Truncate)
+	TimeTypeObj.AddMethod(Time_Round)
+	TimeTypeObj.AddMethod(Time_Year)
+	TimeTypeObj.AddMethod(Time_Month)
+	TimeTypeObj.AddMethod(Time_Day)
+	TimeTypeObj.AddMethod(Time_Hour)
+	TimeTypeObj.AddMethod(Time_Minute)
+	TimeTypeObj.AddMethod(Time_Second)
+	TimeTypeObj.AddMethod(Time_Millisecond)
+	TimeTypeObj.AddMethod(Time_Weekday)
+	TimeTypeObj.AddMethod(Time_YearDay)
+	TimeTypeObj.AddMethod(Time_Unix)
+	TimeTypeObj.AddMethod(Time_UnixMilli)
+}
+
+func (o *TimeType) Instantiate(scope *Scope) Object {
+	return scope.Interrupt(Raise("cannot instantiate type 'Time' manually, use 'Time.Now', 'Time.Date' or 'Time.Parse'"))
+}
+
+// Convert accepts a number of seconds since the Unix epoch, or a string in one
+// of the layouts accepted by `Time.Parse`.
+func (o *TimeType) Convert(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *Time:
+		return obj
+	case *Number:
+		return NewTime(time.UnixMilli(int64(obj.Value * 1000)).UTC())
+	case *String:
+		return Time_Parse.Call(scope, obj)
+	}
+	return scope.Interrupt(Raise("cannot convert '%s' to 'Time'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// Time is an instant with nanosecond precision, in a time zone.
+type Time struct {
+	*BaseObject
+	Value time.Time
+}
+
+func NewTime(t time.Time) *Time {
+	return &Time{
+		BaseObject: NewBaseObject(TimeTypeObj),
+		Value:      t,
+	}
+}
+
+// OnOperator moves the instant by durations, and subtracts instants,
+// returning the duration between them.
+func (o *Time) OnOperator(scope *Scope, op string, right Object) Object {
+	switch right := right.(type) {
+	case *Duration:
+		switch op {
+		case "+":
+			return NewTime(o.Value.Add(right.Value))
+		case "-":
+			return NewTime(o.Value.Add(-right.Value))
+		}
+
+	case *Time:
+		if op == "-" {
+			return NewDuration(o.Value.Sub(right.Value))
+		}
+	}
+
+	if op == "+" || op == "-" {
+		return operandError(scope, op, o, right)
+	}
+	return OrderOperator(scope, op, o, right)
+}
+
+func (o *Time) AsBool() bool {
+	return !o.Value.IsZero()
+}
+
+func (o *Time) AsString() string {
+	return o.Value.Format(time.RFC3339Nano)
+}
+
+func (o *Time) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *Time) AsInterface() any {
+	return o.Value
+}
+
+// ----------------------------------------------------------------------------
+// Layouts and zones. Layouts are Go layouts, like '2006-01-02 15:04', the name
+// of a standard layout, like 'RFC1123', or strftime patterns, like
+// '%Y-%m-%d %H:%M', when they contain a `%`. Zones are IANA names, like
+// 'America/Sao_Paulo', 'UTC', 'Local', or fixed offsets, like '-03:00'.
+// ----------------------------------------------------------------------------
+
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// The layouts tried by `Time.Parse` when none is given. Fractional seconds
+// are accepted after the seconds even if the layout does not have them.
+var defaultTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.ANSIC,
+}
+
+// The strftime directives and their Go layouts. `%f` (microseconds) and `%s`
+// (Unix seconds) are handled apart.
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'Z': "MST",
+	'z': "-0700",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'R': "15:04",
+	'%': "%",
+}
+
+// Returns the Go layout of the layout name or strftime pattern.
+func timeLayout(layout string) (string, error) {
+	if named, ok := timeLayouts[layout]; ok {
+		return named, nil
+	}
+	if !strings.Contains(layout, "%") {
+		return layout, nil
+	}
+
+	b := &strings.Builder{}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			b.WriteByte(layout[i])
+			continue
+		}
+		if i++; i == len(layout) {
+			return "", fmt.Errorf("incomplete directive at the end of '%s'", layout)
+		}
+
+		switch c := layout[i]; {
+		case c == 'f' && (strings.HasSuffix(b.String(), ".") || strings.HasSuffix(b.String(), ",")):
+			b.WriteString("000000")
+		case c == 'f' || c == 's':
+			return "", fmt.Errorf("directive '%%%c' cannot be used to parse", c)
+		case strftimeDirectives[c] != "":
+			b.WriteString(strftimeDirectives[c])
+		default:
+			return "", fmt.Errorf("unknown directive '%%%c'", c)
+		}
+	}
+	return b.String(), nil
+}
+
+// Formats the instant with the layout. strftime patterns are formatted one
+// directive at a time, so their literal text is never read as a Go layout.
+func formatTime(t time.Time, layout string) (string, error) {
+	if _, ok := timeLayouts[layout]; ok || !strings.Contains(layout, "%") {
+		layout, _ = timeLayout(layout)
+		return t.Format(layout), nil
+	}
+
+	b := &strings.Builder{}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			b.WriteByte(layout[i])
+			continue
+		}
+		if i++; i == len(layout) {
+			return "", fmt.Errorf("incomplete directive at the end of '%s'", layout)
+		}
+
+		switch c := layout[i]; {
+		case c == 'f':
+			fmt.Fprintf(b, "%06d", t.Nanosecond()/1000)
+		case c == 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case c == '%':
+			b.WriteByte('%')
+		case strftimeDirectives[c] != "":
+			b.WriteString(t.Format(strftimeDirectives[c]))
+		default:
+			return "", fmt.Errorf("unknown directive '%%%c'", c)
+		}
+	}
+	return b.String(), nil
+}
+
+func loadZone(name string) (*time.Location, error) {
+	if len(name) == 6 && (name[0] == '+' || name[0] == '-') && name[3] == ':' {
+		hours, err1 := strconv.Atoi(name[1:3])
+		minutes, err2 := strconv.Atoi(name[4:])
+		if err1 == nil && err2 == nil {
+			offset := hours*3600 + minutes*60
+			if name[0] == '-' {
+				offset = -offset
+			}
+			return time.FixedZone(name, offset), nil
+		}
+	}
+
+	zone, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s'", name)
+	}
+	return zone, nil
+}
+
+// Returns the zone given as an optional argument, or the default.
+func zoneArg(scope *Scope, args []Object, i int, def *time.Location) (*time.Location, Object) {
+	if len(args) <= i {
+		return def, nil
+	}
+	name, ok := args[i].(*String)
+	if !ok {
+		return nil, scope.Interrupt(Raise("expected the name of a time zone, got '%s'", args[i].TypeId()))
+	}
+	zone, err := loadZone(name.Value)
+	if err != nil {
+		return nil, scope.Interrupt(Raise("%s", err.Error()))
+	}
+	return zone, nil
+}
+
+// Applies fn to the wall clock of the instant, as if it were in UTC, so
+// truncating and rounding follow the days and hours of its zone.
+func onWallClock(t time.Time, fn func(time.Time) time.Time) time.Time {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	wall = fn(wall)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), t.Location())
+}
+
+// Returns the duration of a unit name, like 'day', or of a Duration.
+func unitOf(scope *Scope, obj Object) (time.Duration, Object) {
+	if name, ok := obj.(*String); ok {
+		switch name.Value {
+		case "day":
+			return 24 * time.Hour, nil
+		case "hour":
+			return time.Hour, nil
+		case "minute":
+			return time.Minute, nil
+		case "second":
+			return time.Second, nil
+		case "millisecond":
+			return time.Millisecond, nil
+		}
+		return 0, scope.Interrupt(Raise("unknown unit '%s', expected year, month, day, hour, minute, second or millisecond", name.Value))
+	}
+	return durationOf(scope, obj)
+}
+
+// ----------------------------------------------------------------------------
+// Type Functions
+// ----------------------------------------------------------------------------
+var Time_Now = F(
+	func(scope *Scope, args ...Object) Object {
+		now := scope.Clock().Now()
+		zone, err := zoneArg(scope, args, 0, now.Location())
+		if err != nil {
+			return err
+		}
+		return NewTime(now.In(zone))
+	},
+	`Now`,
+	`Returns the current instant, in the local zone or in the given one.`,
+	P("zone"),
+)
+
+var Time_Parse = F(
+	func(scope *Scope, args ...Object) Object {
+		text := strings.TrimSpace(args[0].AsString())
+		zone, err := zoneArg(scope, args, 2, time.UTC)
+		if err != nil {
+			return err
+		}
+
+		layouts := defaultTimeLayouts
+		if len(args) > 1 {
+			layout, err := timeLayout(args[1].AsString())
+			if err != nil {
+				return scope.Interrupt(Raise("invalid layout: %s", err.Error()))
+			}
+			layouts = []string{layout}
+		}
+
+		for _, layout := range layouts {
+			if t, err := time.ParseInLocation(layout, text, zone); err == nil {
+				return NewTime(t)
+			}
+		}
+		if len(args) > 1 {
+			return scope.Interrupt(Raise("cannot parse '%s' as '%s'", text, args[1].AsString()))
+		}
+		return scope.Interrupt(Raise("cannot parse '%s' as a time, give its layout", text))
+	},
+	`Parse`,
+	`Parses the text with the layout, or with the common layouts, like '2024-03-01T12:00:00Z' and '2024-03-01 12:00', if not given. Texts without an offset are in the zone, UTC by default.`,
+	P("text", V.Type(StringId)),
+	P("layout"),
+	P("zone"),
+)
+
+var Time_Date = F(
+	func(scope *Scope, args ...Object) Object {
+		var parts [6]int
+		for i := range parts {
+			if i >= len(args) {
+				break
+			}
+			n, ok := args[i].(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("expected a number, got '%s'", args[i].TypeId()))
+			}
+			parts[i] = int(n.Value)
+		}
+
+		zone, err := zoneArg(scope, args, 6, time.UTC)
+		if err != nil {
+			return err
+		}
+		return NewTime(time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], 0, zone))
+	},
+	`Date`,
+	`Returns the instant of the date and time in the zone, UTC by default. Values out of range are normalized, so the day 32 of january is the first of february.`,
+	P("year", V.Type(NumberId)),
+	P("month", V.Type(NumberId)),
+	P("day", V.Type(NumberId)),
+	P("hour"),
+	P("minute"),
+	P("second"),
+	P("zone"),
+)
+
+var Time_Since = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		return NewDuration(scope.Clock().Now().Sub(this.Value))
+	},
+	`Since`,
+	`Returns the duration elapsed since the instant.`,
+	P("time", V.Type(TimeId)),
+)
+
+var Time_Sleep = F(
+	func(scope *Scope, args ...Object) Object {
+		return Sleep.Call(scope, args[0])
+	},
+	`Sleep`,
+	`Pauses the execution for the duration, a Duration or a number of milliseconds.`,
+	P("duration"),
+)
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var Time_Format = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		if len(args) < 2 {
+			return NewString(this.AsString())
+		}
+
+		s, err := formatTime(this.Value, args[1].AsString())
+		if err != nil {
+			return scope.Interrupt(Raise("invalid layout: %s", err.Error()))
+		}
+		return NewString(s)
+	},
+	`Format`,
+	`Returns the instant as text in the layout, RFC 3339 if not given.`,
+	P("this", V.Type(TimeId)),
+	P("layout"),
+)
+
+var Time_In = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		zone, err := zoneArg(scope, args, 1, nil)
+		if err != nil {
+			return err
+		}
+		return NewTime(this.Value.In(zone))
+	},
+	`In`,
+	`Returns the same instant in the zone.`,
+	P("this", V.Type(TimeId)),
+	P("zone", V.Type(StringId)),
+)
+
+var Time_Utc = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		return NewTime(this.Value.UTC())
+	},
+	`Utc`,
+	`Returns the same instant in UTC.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Local = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		return NewTime(this.Value.Local())
+	},
+	`Local`,
+	`Returns the same instant in the local zone.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Zone = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		name, offset := this.Value.Zone()
+		return NewTuple(NewString(name), NewDuration(time.Duration(offset)*time.Second))
+	},
+	`Zone`,
+	`Returns the abbreviated name of the zone of the instant, like 'BRT', and its offset from UTC as a duration.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Add = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		d, err := durationOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewTime(this.Value.Add(d))
+	},
+	`Add`,
+	`Returns the instant moved by the duration, like 'time + duration'.`,
+	P("this", V.Type(TimeId)),
+	P("duration"),
+)
+
+var Time_AddDate = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		var parts [3]int
+		for i := range parts {
+			if i+1 >= len(args) {
+				break
+			}
+			n, ok := args[i+1].(*Number)
+			if !ok {
+				return scope.Interrupt(Raise("expected a number, got '%s'", args[i+1].TypeId()))
+			}
+			parts[i] = int(n.Value)
+		}
+		return NewTime(this.Value.AddDate(parts[0], parts[1], parts[2]))
+	},
+	`AddDate`,
+	`Returns the instant moved by the years, months and days, keeping the time of the day. Dates out of range are normalized, so adding a month to october 31 gives december 1.`,
+	P("this", V.Type(TimeId)),
+	P("years", V.Type(NumberId)),
+	P("months"),
+	P("days"),
+)
+
+var Time_Sub = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		other := args[1].(*Time)
+		return NewDuration(this.Value.Sub(other.Value))
+	},
+	`Sub`,
+	`Returns the duration from the other instant to this one, like 'time - other'.`,
+	P("this", V.Type(TimeId)),
+	P("other", V.Type(TimeId)),
+)
+
+var Time_Truncate = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		t := this.Value
+		switch args[1].AsString() {
+		case "year":
+			return NewTime(time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location()))
+		case "month":
+			return NewTime(time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()))
+		}
+
+		d, err := unitOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewTime(onWallClock(t, func(wall time.Time) time.Time {
+			return wall.Truncate(d)
+		}))
+	},
+	`Truncate`,
+	`Returns the instant rounded down to the unit, like 'day' or 'hour', or to a multiple of the duration since midnight, in the zone of the instant.`,
+	P("this", V.Type(TimeId)),
+	P("unit"),
+)
+
+var Time_Round = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*Time)
+		d, err := unitOf(scope, args[1])
+		if err != nil {
+			return err
+		}
+		return NewTime(onWallClock(this.Value, func(wall time.Time) time.Time {
+			return wall.Round(d)
+		}))
+	},
+	`Round`,
+	`Returns the instant rounded to the nearest unit, like 'hour', or multiple of the duration, in the zone of the instant. Halfway values are rounded up.`,
+	P("this", V.Type(TimeId)),
+	P("unit"),
+)
+
+var Time_Year = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Year()))
+	},
+	`Year`,
+	`Returns the year of the instant.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Month = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Month()))
+	},
+	`Month`,
+	`Returns the month of the instant, from 1 to 12.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Day = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Day()))
+	},
+	`Day`,
+	`Returns the day of the month of the instant.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Hour = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Hour()))
+	},
+	`Hour`,
+	`Returns the hour of the instant, from 0 to 23.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Minute = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Minute()))
+	},
+	`Minute`,
+	`Returns the minute of the instant.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Second = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Second()))
+	},
+	`Second`,
+	`Returns the second of the instant, without the fraction.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Millisecond = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.Nanosecond() / int(time.Millisecond)))
+	},
+	`Millisecond`,
+	`Returns the milliseconds of the second of the instant.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Weekday = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewString(args[0].(*Time).Value.Weekday().String())
+	},
+	`Weekday`,
+	`Returns the name of the day of the week of the instant, like 'Monday'.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_YearDay = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.YearDay()))
+	},
+	`YearDay`,
+	`Returns the day of the year of the instant, from 1 to 366.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_Unix = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.UnixMilli()) / 1000)
+	},
+	`Unix`,
+	`Returns the number of seconds since the Unix epoch, with the milliseconds as the fraction.`,
+	P("this", V.Type(TimeId)),
+)
+
+var Time_UnixMilli = F(
+	func(scope *Scope, args ...Object) Object {
+		return NewNumber(float64(args[0].(*Time).Value.UnixMilli()))
+	},
+	`UnixMilli`,
+	`Returns the number of milliseconds since the Unix epoch.`,
+	P("this", V.Type(TimeId)),
+)
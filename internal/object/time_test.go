@@ -0,0 +1,114 @@
+package object_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/internal/object"
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestTime_Now(t *testing.T) {
+	common.AssertTimedCode(t, ` Time.Now()`, `1970-01-01T00:00:00Z`)
+	common.AssertTimedCode(t, ` a := Time.Now(); Time.Sleep(Duration('1h30m')); Time.Now() - a`, `1h30m0s`)
+	common.AssertTimedCode(t, ` a := Time.Now(); sleep(Duration.Minute * 2); Time.Since(a)`, `2m0s`)
+	common.AssertTimedCode(t, ` Time.Now('-03:00')`, `1969-12-31T21:00:00-03:00`)
+	common.AssertCodeError(t, ` Time.Now('Nowhere/City')`)
+}
+
+func TestTime_Clock(t *testing.T) {
+	clock := object.NewFakeClock(time.Unix(0, 0))
+	clock.Set(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	clock.Advance(time.Minute)
+	if got := clock.Now(); !got.Equal(time.Date(2024, 3, 1, 12, 1, 0, 0, time.UTC)) {
+		t.Errorf("expected 2024-03-01 12:01, got %s", got)
+	}
+}
+
+func TestTime_Parse(t *testing.T) {
+	common.AssertCode(t, ` Time.Parse('2024-03-01T12:30:00Z')`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('2024-03-01T12:30:00.250+02:00')`, `2024-03-01T12:30:00.25+02:00`)
+	common.AssertCode(t, ` Time.Parse('2024-03-01 12:30')`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('2024-03-01')`, `2024-03-01T00:00:00Z`)
+	common.AssertCode(t, ` Time('2024-03-01 12:30:05')`, `2024-03-01T12:30:05Z`)
+	common.AssertCode(t, ` Time(1709296200)`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('01/03/2024 12h30', '02/01/2006 15h04')`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('01/Mar/2024:12:30:00 +0000', '%d/%b/%Y:%H:%M:%S %z')`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('2024-03-01 12:30:00.123456', '%F %T.%f')`, `2024-03-01T12:30:00.123456Z`)
+	common.AssertCode(t, ` Time.Parse('Fri, 01 Mar 2024 12:30:00 GMT', 'RFC1123')`, `2024-03-01T12:30:00Z`)
+	common.AssertCode(t, ` Time.Parse('2024-03-01 12:30', '%Y-%m-%d %H:%M', 'America/Sao_Paulo')`, `2024-03-01T12:30:00-03:00`)
+	common.AssertCodeError(t, ` Time.Parse('yesterday')`)
+	common.AssertCodeError(t, ` Time.Parse('2024-03-01', '%Y-%m-%d %H')`)
+	common.AssertCodeError(t, ` Time.Parse('2024', '%Q')`)
+	common.AssertCodeError(t, ` Time {}`)
+}
+
+func TestTime_Format(t *testing.T) {
+	var instant = ` t := Time.Date(2024, 3, 1, 9, 5, 7);`
+	common.AssertCode(t, instant+` t.Format()`, `2024-03-01T09:05:07Z`)
+	common.AssertCode(t, instant+` t.Format('2006-01-02 15:04')`, `2024-03-01 09:05`)
+	common.AssertCode(t, instant+` t.Format('Kitchen')`, `9:05AM`)
+	common.AssertCode(t, instant+` t.Format('%Y-%m-%d %H:%M:%S')`, `2024-03-01 09:05:07`)
+	common.AssertCode(t, instant+` t.Format('%A, %B %e at %I%p, day %j of 2024, %s')`, `Friday, March  1 at 09AM, day 061 of 2024, 1709283907`)
+	common.AssertCode(t, instant+` t.Format('%%Y 100%%')`, `%Y 100%`)
+	common.AssertCodeError(t, instant+` t.Format('%Q')`)
+}
+
+func TestTime_Zones(t *testing.T) {
+	var instant = ` t := Time.Date(2024, 7, 1, 12, 0, 0);`
+	common.AssertCode(t, instant+` t.In('America/New_York')`, `2024-07-01T08:00:00-04:00`)
+	common.AssertCode(t, instant+` t.In('Asia/Kolkata').Format('%H:%M %Z')`, `17:30 IST`)
+	common.AssertCode(t, instant+` t.In('+05:30').Utc()`, `2024-07-01T12:00:00Z`)
+	common.AssertCode(t, instant+` t.In('Europe/Paris').Zone()`, `('CEST', 2h0m0s)`)
+	common.AssertCode(t, instant+` t.In('Europe/Paris') == t`, `true`)
+	common.AssertCode(t, ` Time.Date(2024, 7, 1, 12, 0, 0, 'Europe/Paris')`, `2024-07-01T12:00:00+02:00`)
+	common.AssertCodeError(t, instant+` t.In('Mars/Olympus')`)
+}
+
+func TestTime_Arithmetic(t *testing.T) {
+	var instant = ` t := Time.Date(2024, 3, 1, 12, 0, 0);`
+	common.AssertCode(t, instant+` t + Duration('1h30m')`, `2024-03-01T13:30:00Z`)
+	common.AssertCode(t, instant+` t - Duration.Day`, `2024-02-29T12:00:00Z`)
+	common.AssertCode(t, instant+` Time.Date(2024, 3, 2) - t`, `12h0m0s`)
+	common.AssertCode(t, instant+` t.Add(1500).Sub(t)`, `1.5s`)
+	common.AssertCode(t, instant+` t.AddDate(0, 1)`, `2024-04-01T12:00:00Z`)
+	common.AssertCode(t, instant+` t.AddDate(1, 0, -1)`, `2025-02-28T12:00:00Z`)
+	common.AssertCodeError(t, instant+` t + 1`)
+	common.AssertCodeError(t, instant+` t * Duration.Hour`)
+	common.AssertCode(t, instant+` (t - 5)?.Error()`, `cannot subtract 'Number' from 'Time'`)
+	common.AssertCode(t, instant+` (t + 'soon')?.Error()`, `cannot add 'String' to 'Time'`)
+}
+
+func TestTime_Compare(t *testing.T) {
+	var instants = ` a := Time.Date(2024, 3, 1); b := Time.Date(2024, 3, 2);`
+	common.AssertCode(t, instants+` [a < b, a > b, a <= a, a == b, a != b]`, `[true, false, true, false, true]`)
+	common.AssertCode(t, instants+` [b, a] | sortBy(fn (x) { x }) | map(fn (x) { x.Day() }) | List`, `[1, 2]`)
+	common.AssertCode(t, instants+` d := {}; d[a] = 1; d[Time.Parse('2024-03-01T03:00:00+03:00')]`, `1`)
+	common.AssertCodeError(t, instants+` a < Duration.Hour`)
+}
+
+func TestTime_TruncateRound(t *testing.T) {
+	var instant = ` t := Time.Date(2024, 3, 15, 17, 45, 30);`
+	common.AssertCode(t, instant+` t.Truncate('day')`, `2024-03-15T00:00:00Z`)
+	common.AssertCode(t, instant+` t.Truncate('month')`, `2024-03-01T00:00:00Z`)
+	common.AssertCode(t, instant+` t.Truncate('year')`, `2024-01-01T00:00:00Z`)
+	common.AssertCode(t, instant+` t.Truncate(Duration('15m'))`, `2024-03-15T17:45:00Z`)
+	common.AssertCode(t, instant+` t.Round('hour')`, `2024-03-15T18:00:00Z`)
+	common.AssertCode(t, instant+` t.In('+05:30').Truncate('hour')`, `2024-03-15T23:00:00+05:30`)
+	common.AssertCode(t, instant+` t.In('America/Sao_Paulo').Truncate('day')`, `2024-03-15T00:00:00-03:00`)
+	common.AssertCodeError(t, instant+` t.Truncate('fortnight')`)
+}
+
+func TestTime_Parts(t *testing.T) {
+	var instant = ` t := Time.Parse('2024-03-01T09:05:07.250Z');`
+	common.AssertCode(t, instant+` [t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Millisecond()]`, `[2024, 3, 1, 9, 5, 7, 250]`)
+	common.AssertCode(t, instant+` [t.Weekday(), t.YearDay()]`, `['Friday', 61]`)
+	common.AssertCode(t, instant+` t.UnixMilli()`, `1709283907250`)
+	common.AssertCode(t, instant+` t.Unix()`, `1709283907.250000`)
+}
+
+func TestTime_Encoding(t *testing.T) {
+	var instant = ` t := Time.Date(2024, 3, 1, 12, 0, 0);`
+	common.AssertCode(t, instant+` Json.Stringify({at=t})`, `{"at":"2024-03-01T12:00:00Z"}`)
+	common.AssertCode(t, instant+` Yaml.Stringify({at=t})`, "at: 2024-03-01T12:00:00Z\n")
+}
@@ -0,0 +1,134 @@
+package object
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// PipeTracer records the elements yielded by each pipe call site and the time
+// spent in it. The time of a stage includes pulling its upstream, so the self
+// time, which discounts the time spent in the nested stages, is the one that
+// points to the bottleneck.
+//
+// The tracer is only used while holding the interpreter lock. Stages running
+// in parallel, like the ones inside `go` or `pmap`, are measured as well, but
+// their self time may be attributed to the stage waiting for them.
+type PipeTracer struct {
+	stages []*PipeStage
+	index  map[string]*PipeStage
+	active []*traceFrame
+}
+
+// PipeStage holds the metrics of a pipe call site.
+type PipeStage struct {
+	Name   string
+	Line   int
+	Column int
+	Calls  int           // times the call was evaluated
+	Count  int           // elements yielded, when the call returns a stream
+	Total  time.Duration // time spent in the call and pulling its stream
+	Self   time.Duration // total time, discounting the time of the nested stages
+	stream bool
+}
+
+type traceFrame struct {
+	nested time.Duration
+}
+
+func NewPipeTracer() *PipeTracer {
+	return &PipeTracer{
+		index: make(map[string]*PipeStage),
+	}
+}
+
+// Stages returns the metrics of the call sites, in the order they were first
+// evaluated.
+func (t *PipeTracer) Stages() []*PipeStage {
+	return t.stages
+}
+
+// Trace runs the call of a pipe stage. If the call returns a stream, the
+// stream is wrapped so the pulls are measured too.
+func (t *PipeTracer) Trace(scope *Scope, name string, line, column int, call func() Object) Object {
+	stage := t.stage(name, line, column)
+	stage.Calls++
+
+	ret := t.measure(scope, stage, call)
+	stream, ok := ret.(*Stream)
+	if !ok {
+		return ret
+	}
+
+	stage.stream = true
+	return passThrough(stream, NewInternalStream(func(s *Scope) Object {
+		maybe := t.measure(s, stage, func() Object {
+			return Stream_Next.Call(s, stream)
+		})
+		if isRaise(maybe) {
+			return maybe
+		}
+		if stream.Finished {
+			return nil
+		}
+
+		stage.Count++
+		return YieldWith(maybe.(*Maybe).Value)
+	}, scope))
+}
+
+func (t *PipeTracer) stage(name string, line, column int) *PipeStage {
+	key := fmt.Sprintf("%d:%d:%s", line, column, name)
+	stage, ok := t.index[key]
+	if !ok {
+		stage = &PipeStage{Name: name, Line: line, Column: column}
+		t.index[key] = stage
+		t.stages = append(t.stages, stage)
+	}
+	return stage
+}
+
+// Runs fn, adding its duration to the stage and discounting it from the self
+// time of the stage that called it.
+func (t *PipeTracer) measure(scope *Scope, stage *PipeStage, fn func() Object) Object {
+	frame := &traceFrame{}
+	t.active = append(t.active, frame)
+
+	start := scope.Clock().Now()
+	ret := fn()
+	elapsed := scope.Clock().Now().Sub(start)
+
+	if i := slices.Index(t.active, frame); i >= 0 {
+		t.active = slices.Delete(t.active, i, i+1)
+		if i > 0 {
+			t.active[i-1].nested += elapsed
+		}
+	}
+
+	stage.Total += elapsed
+	stage.Self += elapsed - frame.nested
+	return ret
+}
+
+// Report writes a table with the metrics of every stage. Throughput is the
+// number of elements per second of self time.
+func (t *PipeTracer) Report(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "stage\tat\tcalls\telements\tself\ttotal\telements/s\t")
+	for _, stage := range t.stages {
+		count, rate := "-", "-"
+		if stage.stream {
+			count = fmt.Sprint(stage.Count)
+			if stage.Self > 0 {
+				rate = fmt.Sprintf("%.1f", float64(stage.Count)/stage.Self.Seconds())
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%d:%d\t%d\t%s\t%s\t%s\t%s\t\n",
+			stage.Name, stage.Line, stage.Column, stage.Calls, count,
+			stage.Self.Round(time.Microsecond), stage.Total.Round(time.Microsecond), rate)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,53 @@
+package object_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/internal/object"
+	"github.com/renatopp/pipelang/internal/runtime"
+)
+
+func TestPipeTracer(t *testing.T) {
+	r := runtime.New()
+	r.SetClock(object.NewFakeClock(time.Unix(0, 0)))
+	tracer := r.TracePipes()
+
+	_, err := r.RunCode([]byte(`slow := fn(x) { sleep(100); return x }
+fast := fn(x) { sleep(10); return x }
+range(5) | map slow | map fast | sum`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stages := tracer.Stages()
+	if len(stages) != 3 {
+		t.Fatalf("expected 3 stages, got %d", len(stages))
+	}
+
+	expected := []struct {
+		name        string
+		count       int
+		self, total time.Duration
+	}{
+		{"map", 5, 500 * time.Millisecond, 500 * time.Millisecond},
+		{"map", 5, 50 * time.Millisecond, 550 * time.Millisecond},
+		{"sum", 0, 0, 550 * time.Millisecond},
+	}
+	for i, e := range expected {
+		stage := stages[i]
+		if stage.Name != e.name || stage.Count != e.count || stage.Self != e.self || stage.Total != e.total {
+			t.Errorf("stage %d: expected %s %d %s/%s, got %s %d %s/%s", i, e.name, e.count, e.self, e.total, stage.Name, stage.Count, stage.Self, stage.Total)
+		}
+	}
+	if stages[0].Line != 3 || stages[0].Column != 10 {
+		t.Errorf("expected stage at 3:10, got %d:%d", stages[0].Line, stages[0].Column)
+	}
+
+	report := &strings.Builder{}
+	tracer.Report(report)
+	if !strings.Contains(report.String(), "elements/s") || !strings.Contains(report.String(), "10.0") {
+		t.Errorf("unexpected report:\n%s", report.String())
+	}
+}
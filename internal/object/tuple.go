@@ -59,6 +59,33 @@ func NewTuple(e ...Object) *Tuple {
 	}
 }
 
+func (o *Tuple) OnIndex(scope *Scope, t *Tuple) Object {
+	if len(t.Elements) != 1 {
+		return scope.Interrupt(Raise("invalid tuple index '%s'", t.AsString()))
+	}
+
+	switch index := t.Elements[0].(type) {
+	case *Slice:
+		elements, err := selectSlice(scope, index, o.Elements)
+		if err != nil {
+			return err
+		}
+		return NewTuple(elements...)
+
+	case *Number:
+		i := int(index.Value)
+		if i < 0 {
+			i += len(o.Elements)
+		}
+		if i < 0 || i >= len(o.Elements) {
+			return scope.Interrupt(Raise("index out of range"))
+		}
+		return o.Elements[i]
+	}
+
+	return scope.Interrupt(Raise("invalid tuple index '%s'", t.AsString()))
+}
+
 func (o *Tuple) AsBool() bool {
 	return o.Elements[0].AsBool()
 	// return true
@@ -76,6 +103,10 @@ func (o *Tuple) AsInterface() any {
 	return o.AsString()
 }
 
+func (o *Tuple) OnOperator(scope *Scope, op string, right Object) Object {
+	return OrderOperator(scope, op, o, right)
+}
+
 func (o *Tuple) AsRepr() string {
 	return o.AsString()
 }
@@ -0,0 +1,63 @@
+package object
+
+// ----------------------------------------------------------------------------
+// Type Annotations - used by the evaluator to enforce annotations such as
+// `fn add(a: Number): Number` and `a: String := 'foo'`.
+// ----------------------------------------------------------------------------
+
+// IsTypeObject returns true if the object can be used as a type annotation,
+// like `Number` or a data type.
+func IsTypeObject(obj Object) bool {
+	if _, ok := obj.(*DataType); ok {
+		return true
+	}
+
+	_, ok := obj.(ObjectType)
+	return ok && obj.Type() != nil && obj.Type().TypeId() == TypeId
+}
+
+// IsInstanceOf returns true if the object is an instance of the given type.
+// Data instances are also instances of the data types they extend.
+func IsInstanceOf(obj Object, tp Object) bool {
+	if dt, ok := tp.(*DataType); ok {
+		other, ok := obj.Type().(*DataType)
+		return ok && obj.TypeId() == DataId && other.Is(dt)
+	}
+
+	return obj.TypeId() == tp.TypeId()
+}
+
+// TypeName returns the name of the object's type, using the data type name for
+// data instances.
+func TypeName(obj Object) string {
+	if dt, ok := obj.Type().(*DataType); ok && obj.TypeId() == DataId && dt.Name != "" {
+		return dt.Name
+	}
+
+	return string(obj.TypeId())
+}
+
+// TypeObjectName returns the name of a type object, like `Number` or the name
+// of a data type.
+func TypeObjectName(tp Object) string {
+	if dt, ok := tp.(*DataType); ok && dt.Name != "" {
+		return dt.Name
+	}
+
+	return string(tp.TypeId())
+}
+
+// CheckType raises if the object is not an instance of the given type. The
+// description identifies the annotated element in the error message, like
+// `parameter 'a'`.
+func CheckType(scope *Scope, description string, obj Object, tp Object) Object {
+	if !IsTypeObject(tp) {
+		return scope.Interrupt(Raise("invalid type annotation for %s: '%s' is not a type", description, tp.AsString()))
+	}
+
+	if !IsInstanceOf(obj, tp) {
+		return scope.Interrupt(Raise("expected %s to be of type '%s', got '%s' instead", description, TypeObjectName(tp), TypeName(obj)))
+	}
+
+	return nil
+}
@@ -0,0 +1,368 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var XmlElementId = TypeIdentifier("XmlElement")
+var XmlElementTypeObj = NewXmlElementType()
+
+// ----------------------------------------------------------------------------
+// Type Definition - represents the type instance in Pipe, like `Number`,
+// `String` or even `Type`.
+// ----------------------------------------------------------------------------
+type XmlElementType struct {
+	*BaseObjectType
+}
+
+func NewXmlElementType() *XmlElementType {
+	t := &XmlElementType{
+		BaseObjectType: NewBaseObjectType(
+			NewBaseObject(TypeTypeObj),
+			XmlElementId,
+		),
+	}
+
+	t.AddMethod(XmlElement_Find)
+	t.AddMethod(XmlElement_FindAll)
+	t.AddMethod(XmlElement_Attr)
+
+	return t
+}
+
+// Instantiate creates an empty element, as in `XmlElement { tag='item' }`.
+func (o *XmlElementType) Instantiate(scope *Scope) Object {
+	return NewXmlElement("", NewDict(map[string]Object{}), NewList(), "")
+}
+
+func (o *XmlElementType) Convert(scope *Scope, obj Object) Object {
+	switch obj := obj.(type) {
+	case *XmlElement:
+		return obj
+	case *String:
+		return Module_Xml_Parse.Call(scope, obj)
+	}
+	return scope.Interrupt(Raise("cannot convert '%s' to 'XmlElement'", obj.TypeId()))
+}
+
+// ----------------------------------------------------------------------------
+// Instance Definition - represents the instance of a particular type in Pipe,
+// like `1` and `'foo'`.
+// ----------------------------------------------------------------------------
+
+// XmlElement is a node of an XML document. Its tag, attributes, children and
+// text are properties, so they can be changed by the scripts like the
+// attributes of data instances.
+type XmlElement struct {
+	*BaseObject
+}
+
+func NewXmlElement(tag string, attributes *Dict, children *List, text string) *XmlElement {
+	el := &XmlElement{
+		BaseObject: NewBaseObject(XmlElementTypeObj),
+	}
+	el.SetProperty("tag", NewString(tag))
+	el.SetProperty("attributes", attributes)
+	el.SetProperty("children", children)
+	el.SetProperty("text", NewString(text))
+	return el
+}
+
+func (o *XmlElement) Tag() string {
+	return o.GetProperty("tag").AsString()
+}
+
+func (o *XmlElement) Text() string {
+	return o.GetProperty("text").AsString()
+}
+
+func (o *XmlElement) Attributes() *Dict {
+	return o.GetProperty("attributes").(*Dict)
+}
+
+// Children returns the child elements, ignoring other values added to the
+// children list.
+func (o *XmlElement) Children() []*XmlElement {
+	var children []*XmlElement
+	for _, child := range o.GetProperty("children").(*List).Elements {
+		if el, ok := child.(*XmlElement); ok {
+			children = append(children, el)
+		}
+	}
+	return children
+}
+
+func (o *XmlElement) AsBool() bool {
+	return true
+}
+
+func (o *XmlElement) AsString() string {
+	return fmt.Sprintf("<element '%s'>", o.Tag())
+}
+
+func (o *XmlElement) AsRepr() string {
+	return o.AsString()
+}
+
+func (o *XmlElement) AsInterface() any {
+	return o.AsString()
+}
+
+// ----------------------------------------------------------------------------
+// Path queries, a subset of XPath relative to the element:
+//
+// - `a/b` selects the `b` children of the `a` children;
+// - `*` selects any tag, `.` the element itself, and `//b` the `b`
+//   descendants at any depth;
+// - `[@x]` and `[@x='v']` keep the elements with the attribute, `[c]` and
+//   `[c='v']` the ones with a child `c`, and `[2]` the second element of each
+//   parent, starting at 1.
+// ----------------------------------------------------------------------------
+
+type xmlStep struct {
+	descendants bool
+	tag         string
+	predicates  []xmlPredicate
+}
+
+type xmlPredicate struct {
+	attr     string // `[@attr]`
+	child    string // `[child]`
+	value    string
+	hasValue bool
+	index    int // `[n]`, 0 if not given
+}
+
+func parseXmlPath(path string) ([]xmlStep, error) {
+	var steps []xmlStep
+	rest := strings.TrimPrefix(path, "./")
+	descendants := false
+	if strings.HasPrefix(rest, "//") {
+		descendants, rest = true, rest[2:]
+	}
+
+	for {
+		step := xmlStep{descendants: descendants}
+		i := strings.IndexAny(rest, "[/")
+		if i < 0 {
+			i = len(rest)
+		}
+		step.tag, rest = rest[:i], rest[i:]
+		if step.tag == "" {
+			return nil, errors.New("expected a tag")
+		}
+
+		for strings.HasPrefix(rest, "[") {
+			end := xmlPredicateEnd(rest)
+			if end < 0 {
+				return nil, errors.New("unterminated '['")
+			}
+			pred, err := parseXmlPredicate(rest[1:end])
+			if err != nil {
+				return nil, err
+			}
+			step.predicates = append(step.predicates, pred)
+			rest = rest[end+1:]
+		}
+		steps = append(steps, step)
+
+		switch {
+		case rest == "":
+			return steps, nil
+		case strings.HasPrefix(rest, "//"):
+			descendants, rest = true, rest[2:]
+		case strings.HasPrefix(rest, "/"):
+			descendants, rest = false, rest[1:]
+		default:
+			return nil, fmt.Errorf("unexpected '%s'", rest)
+		}
+	}
+}
+
+// Returns the index of the `]` closing the predicate, skipping quoted values.
+func xmlPredicateEnd(s string) int {
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseXmlPredicate(s string) (xmlPredicate, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return xmlPredicate{}, errors.New("indices start at 1")
+		}
+		return xmlPredicate{index: n}, nil
+	}
+
+	pred := xmlPredicate{}
+	name, value, hasValue := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if hasValue {
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return pred, fmt.Errorf("expected a quoted value in '[%s]'", s)
+		}
+		pred.value, pred.hasValue = value[1:len(value)-1], true
+	}
+
+	if strings.HasPrefix(name, "@") {
+		pred.attr = name[1:]
+	} else {
+		pred.child = name
+	}
+	if pred.attr == "" && pred.child == "" {
+		return pred, fmt.Errorf("expected a name in '[%s]'", s)
+	}
+	return pred, nil
+}
+
+func (p xmlPredicate) match(el *XmlElement, position int) bool {
+	switch {
+	case p.index > 0:
+		return position == p.index
+
+	case p.attr != "":
+		value, ok := el.Attributes().Elements[p.attr]
+		return ok && (!p.hasValue || value.AsString() == p.value)
+	}
+
+	for _, child := range el.Children() {
+		if child.Tag() == p.child && (!p.hasValue || child.Text() == p.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the elements matching the path, in document order.
+func (o *XmlElement) findAll(path string) ([]*XmlElement, error) {
+	steps, err := parseXmlPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path '%s': %s", path, err.Error())
+	}
+
+	current := []*XmlElement{o}
+	for _, step := range steps {
+		var next []*XmlElement
+		seen := map[*XmlElement]bool{}
+		for _, el := range current {
+			var candidates []*XmlElement
+			switch {
+			case step.tag == ".":
+				candidates = []*XmlElement{el}
+			case step.descendants:
+				candidates = el.descendants(nil)
+			default:
+				candidates = el.Children()
+			}
+
+			position := 0
+			for _, candidate := range candidates {
+				if step.tag != "." && step.tag != "*" && candidate.Tag() != step.tag {
+					continue
+				}
+				position++
+				if seen[candidate] || !step.matches(candidate, position) {
+					continue
+				}
+				seen[candidate] = true
+				next = append(next, candidate)
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func (s xmlStep) matches(el *XmlElement, position int) bool {
+	for _, pred := range s.predicates {
+		if !pred.match(el, position) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *XmlElement) descendants(result []*XmlElement) []*XmlElement {
+	for _, child := range o.Children() {
+		result = append(result, child)
+		result = child.descendants(result)
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Instance Methods
+// ----------------------------------------------------------------------------
+var XmlElement_Find = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*XmlElement)
+		path := args[1].AsString()
+		found, err := this.findAll(path)
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+		if len(found) == 0 {
+			return NewMaybe(NewErrorFromString("no element matches '" + path + "'"))
+		}
+		return NewMaybe(found[0])
+	},
+	`Find`,
+	`Returns the first element matching the path as a Maybe, like 'channel/item[@id]'.`,
+	P("this", V.Type(XmlElementId)),
+	P("path", V.Type(StringId)),
+)
+
+var XmlElement_FindAll = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*XmlElement)
+		found, err := this.findAll(args[1].AsString())
+		if err != nil {
+			return scope.Interrupt(Raise("%s", err.Error()))
+		}
+
+		elements := make([]Object, len(found))
+		for i, el := range found {
+			elements[i] = el
+		}
+		return NewList(elements...)
+	},
+	`FindAll`,
+	`Returns the list of elements matching the path, like 'testsuite/testcase[@status]'.`,
+	P("this", V.Type(XmlElementId)),
+	P("path", V.Type(StringId)),
+)
+
+var XmlElement_Attr = F(
+	func(scope *Scope, args ...Object) Object {
+		this := args[0].(*XmlElement)
+		value, ok := this.Attributes().Elements[args[1].AsString()]
+		switch {
+		case ok:
+			return value
+		case len(args) > 2:
+			return args[2]
+		}
+		return NewString("")
+	},
+	`Attr`,
+	`Returns the value of the attribute, or the default, an empty string if not given.`,
+	P("this", V.Type(XmlElementId)),
+	P("name", V.Type(StringId)),
+	P("default"),
+)
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/renatopp/langtools/parsers"
 	"github.com/renatopp/langtools/tokens"
@@ -32,6 +33,7 @@ type PipeParser struct {
 	conditionLock *Stack[bool] // lock to disable data instantiation and dict creation, use true to lock
 	lambdaLock    *Stack[bool] // lock to disable lambdas, use true to lock
 	pipeLock      *Stack[bool] // lock to disable pipes, use true to lock
+	indexLock     *Stack[bool] // true while parsing an index, where `:` separates the slice parts
 }
 
 func NewPipeParser(lexer *PostLexer) *PipeParser {
@@ -48,6 +50,7 @@ func NewPipeParser(lexer *PostLexer) *PipeParser {
 	p.conditionLock = NewStack[bool]()
 	p.lambdaLock = NewStack[bool]()
 	p.pipeLock = NewStack[bool]()
+	p.indexLock = NewStack[bool]()
 
 	p.registerPrefixFn(T_NUMBER, p.prefixNumber)
 	p.registerPrefixFn(T_HEX_NUMBER, p.prefixHexNumber)
@@ -429,10 +432,25 @@ func (p *PipeParser) prefixBoolean() ast.Node {
 
 func (p *PipeParser) prefixIdentifier() ast.Node {
 	cur := p.Lexer.EatToken()
-	return &ast.Identifier{
+
+	// `select` is only a keyword when followed by its block, so it can still
+	// be used as a name
+	if cur.Literal == "select" && p.Lexer.PeekToken().IsType(T_LBRACE) {
+		return p.parseSelect(cur)
+	}
+
+	ident := &ast.Identifier{
 		Token: cur,
 		Value: cur.Literal,
 	}
+
+	// Typed declarations, like `a: Number := 2`
+	if p.isTypedDeclaration() {
+		p.Lexer.EatToken()
+		ident.Type = p.parseTypeAnnotation()
+	}
+
+	return ident
 }
 
 func (p *PipeParser) prefixOperator() ast.Node {
@@ -454,6 +472,7 @@ func (p *PipeParser) prefixOperator() ast.Node {
 func (p *PipeParser) prefixParenthesis() ast.Node {
 	p.openTupleLock.Push(true)
 	defer p.openTupleLock.Pop()
+	defer p.unlockIndexLambdas()()
 
 	cur := p.Lexer.EatToken()
 	p.conditionLock.Push(false)
@@ -497,6 +516,7 @@ func (p *PipeParser) prefixSpread() ast.Node {
 func (p *PipeParser) prefixBracket() ast.Node {
 	p.openTupleLock.Push(true)
 	defer p.openTupleLock.Pop()
+	defer p.unlockIndexLambdas()()
 
 	cur := p.Lexer.EatToken()
 	expr := p.parseExpressionList(0)
@@ -585,6 +605,12 @@ func (p *PipeParser) prefixKeyword() ast.Node {
 	case "continue":
 		return p.prefixContinue()
 
+	case "defer":
+		return p.prefixDefer()
+
+	case "go":
+		return p.prefixGo()
+
 	case "if":
 		return p.prefixIf()
 
@@ -613,6 +639,7 @@ func (p *PipeParser) prefixFunction() ast.Node {
 
 	name := ""
 	params := []ast.Node{}
+	var returnType ast.Node
 	var body *ast.Block
 
 	// Parse the function name
@@ -630,10 +657,16 @@ func (p *PipeParser) prefixFunction() ast.Node {
 		defer p.openTupleLock.Pop()
 
 		p.Lexer.EatToken()
-		params = p.parseExpressionList(0)
+		params = p.parseParameters()
 		p.skipEoes()
 		p.ExpectType(T_RPAREN)
 		p.Lexer.EatToken()
+
+		// Parse the return type `: Type`
+		if p.Lexer.PeekToken().IsType(T_LAMBDA) {
+			p.Lexer.EatToken()
+			returnType = p.parseTypeAnnotation()
+		}
 	}
 	p.conditionLock.Pop()
 
@@ -658,6 +691,7 @@ func (p *PipeParser) prefixFunction() ast.Node {
 		Parameters: params,
 		Body:       body,
 		Generator:  p.yieldStack.Peek(),
+		ReturnType: returnType,
 	}
 }
 
@@ -673,6 +707,37 @@ func (p *PipeParser) prefixReturn() ast.Node {
 	}
 }
 
+func (p *PipeParser) prefixDefer() ast.Node {
+	cur := p.Lexer.EatToken()
+	expr := p.parseRequiredExpression()
+	if expr == nil {
+		return nil
+	}
+
+	if p.yieldStack.Len() == 0 {
+		p.RegisterErrorWithToken("defer can only be used inside functions", cur)
+		return nil
+	}
+
+	return &ast.Defer{
+		Token:      cur,
+		Expression: expr,
+	}
+}
+
+func (p *PipeParser) prefixGo() ast.Node {
+	cur := p.Lexer.EatToken()
+	expr := p.parseRequiredExpression()
+	if expr == nil {
+		return nil
+	}
+
+	return &ast.Go{
+		Token:      cur,
+		Expression: expr,
+	}
+}
+
 func (p *PipeParser) prefixRaise() ast.Node {
 	cur := p.Lexer.EatToken()
 	expr := p.parseOptionalExpression()
@@ -696,18 +761,35 @@ func (p *PipeParser) prefixYield() ast.Node {
 		}
 	}
 
+	// `yield from <expr>` delegates to another stream
+	delegate := false
+	if next := p.Lexer.PeekToken(); next.IsType(T_IDENTIFIER) && next.IsLiteral("from") && !p.isEndOfExpression(p.Lexer.PeekTokenAt(1)) {
+		p.Lexer.EatToken()
+		delegate = true
+	}
+
 	expr := p.parseRequiredExpression()
 	if expr == nil {
 		expr = &ast.Boolean{Token: cur, Value: false}
 	}
 
+	if p.yieldStack.Len() == 0 {
+		p.RegisterErrorWithToken("yield can only be used inside functions", cur)
+		return nil
+	}
+
 	p.yieldStack.Set(true)
 	return &ast.Yield{
 		Token:      cur,
 		Expression: expr,
+		Delegate:   delegate,
 	}
 }
 
+func (p *PipeParser) isEndOfExpression(token *tokens.Token) bool {
+	return token.IsOneOfTypes(T_EOE, T_EOF, T_COMMA, T_RPAREN, T_RBRACK, T_RBRACE, T_PIPE, T_OPERATOR, T_ASSIGNMENT)
+}
+
 func (p *PipeParser) prefixBreak() ast.Node {
 	cur := p.Lexer.EatToken()
 
@@ -865,12 +947,16 @@ func (p *PipeParser) prefixData() ast.Node {
 
 	p.validateDataBody(body)
 	attributes := map[string]ast.Node{}
+	attributeTypes := map[string]ast.Node{}
 	methods := map[string]ast.Node{}
 	for _, expr := range body.Expressions {
 		switch expr := expr.(type) {
 		case *ast.Assignment:
-			name := expr.Left.(*ast.Identifier).Value
-			attributes[name] = expr.Right
+			ident := expr.Left.(*ast.Identifier)
+			attributes[ident.Value] = expr.Right
+			if ident.Type != nil {
+				attributeTypes[ident.Value] = ident.Type
+			}
 
 		case *ast.FunctionDef:
 			methods[expr.Name] = expr
@@ -883,6 +969,8 @@ func (p *PipeParser) prefixData() ast.Node {
 		Extensions: extensions,
 		Attributes: attributes,
 		Methods:    methods,
+
+		AttributeTypes: attributeTypes,
 	}
 }
 
@@ -936,6 +1024,65 @@ func (p *PipeParser) prefixMatch() ast.Node {
 	}
 }
 
+func (p *PipeParser) parseSelect(cur *tokens.Token) ast.Node {
+	p.ExpectTypes(T_LBRACE)
+	p.Lexer.EatToken()
+
+	p.lambdaLock.Push(true)
+	defer p.lambdaLock.Pop()
+
+	cases := []ast.Node{}
+	names := []string{}
+	for {
+		p.skipEoes()
+		if p.Lexer.PeekToken().IsType(T_RBRACE) {
+			p.Lexer.EatToken()
+			break
+		}
+
+		operation := p.parseRequiredExpression()
+		if operation == nil {
+			break
+		}
+
+		// `ch.Receive() as x` is parsed as an assignment
+		name := ""
+		if assign, ok := operation.(*ast.Assignment); ok {
+			ident, ok := assign.Left.(*ast.Identifier)
+			if !ok || assign.Operator != ":=" {
+				p.RegisterErrorWithToken("expected a variable name in select case", assign.Token)
+			} else {
+				name = ident.Value
+			}
+			operation = assign.Right
+		}
+
+		if ident, ok := operation.(*ast.Identifier); ok && ident.Value == "_" && name != "" {
+			p.RegisterErrorWithToken("default select case cannot have a variable", ident.Token)
+		}
+
+		p.ExpectType(T_LAMBDA)
+		p.Lexer.EatToken()
+
+		var expression ast.Node
+		if p.Lexer.PeekToken().IsType(T_LBRACE) {
+			expression = p.parseBlock()
+		} else {
+			expression = p.parseRequiredExpression()
+		}
+		p.skipEoes()
+
+		cases = append(cases, operation, expression)
+		names = append(names, name)
+	}
+
+	return &ast.Select{
+		Token: cur,
+		Cases: cases,
+		Names: names,
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Infix functions
 // ----------------------------------------------------------------------------
@@ -960,6 +1107,7 @@ func (p *PipeParser) infixOperator(left ast.Node) ast.Node {
 func (p *PipeParser) infixParenthesis(left ast.Node) ast.Node {
 	p.openTupleLock.Push(true)
 	defer p.openTupleLock.Pop()
+	defer p.unlockIndexLambdas()()
 
 	cur := p.Lexer.EatToken()
 	right := p.parseExpressionList(0)
@@ -1107,6 +1255,22 @@ func (p *PipeParser) infixAs(left ast.Node) ast.Node {
 	}
 }
 
+// Lambdas are allowed again inside parentheses, lists, calls and pipe
+// arguments nested in an index, where `:` cannot be a slice separator. Returns
+// the function that restores the locks.
+func (p *PipeParser) unlockIndexLambdas() func() {
+	if !p.indexLock.PeekOr(false) {
+		return func() {}
+	}
+
+	p.indexLock.Push(false)
+	p.lambdaLock.Push(false)
+	return func() {
+		p.lambdaLock.Pop()
+		p.indexLock.Pop()
+	}
+}
+
 func (p *PipeParser) infixPipe(left ast.Node) ast.Node {
 	if p.pipeLock.PeekOr(false) {
 		return nil
@@ -1127,7 +1291,13 @@ func (p *PipeParser) infixPipe(left ast.Node) ast.Node {
 		target = p.infixAccess(target)
 	}
 
+	// `xs[a | f:]` still slices, the `:` cannot start an argument there
+	unlock := func() {}
+	if !p.Lexer.PeekToken().IsType(T_LAMBDA) {
+		unlock = p.unlockIndexLambdas()
+	}
 	args := p.parseExpressionList(p.precedence(cur))
+	unlock()
 
 	return &ast.Call{
 		Token:     cur,
@@ -1157,9 +1327,26 @@ func (p *PipeParser) infixBrace(left ast.Node) ast.Node {
 
 func (p *PipeParser) infixBracket(left ast.Node) ast.Node {
 	cur := p.Lexer.EatToken()
-	index := p.parseRequiredExpression()
-	if index == nil {
-		return nil
+
+	// `:` inside brackets separates the slice parts instead of creating lambdas
+	p.lambdaLock.Push(true)
+	defer p.lambdaLock.Pop()
+	p.indexLock.Push(true)
+	defer p.indexLock.Pop()
+
+	var index ast.Node
+	if !p.Lexer.PeekToken().IsType(T_LAMBDA) {
+		index = p.parseRequiredExpression()
+		if index == nil {
+			return nil
+		}
+	}
+
+	if p.Lexer.PeekToken().IsType(T_LAMBDA) {
+		index = p.parseSlice(index)
+		if index == nil {
+			return nil
+		}
 	}
 
 	p.ExpectType(T_RBRACK)
@@ -1172,6 +1359,104 @@ func (p *PipeParser) infixBracket(left ast.Node) ast.Node {
 	}
 }
 
+// Parses the remaining of a slice `start:stop:step`, with the start already
+// parsed (or nil if omitted).
+func (p *PipeParser) parseSlice(start ast.Node) ast.Node {
+	slice := &ast.Slice{
+		Token: p.Lexer.EatToken(),
+		Start: start,
+	}
+
+	isEnd := func() bool {
+		t := p.Lexer.PeekToken()
+		return t.IsType(T_LAMBDA) || t.IsType(T_RBRACK)
+	}
+
+	if !isEnd() {
+		slice.Stop = p.parseRequiredExpression()
+		if slice.Stop == nil {
+			return nil
+		}
+	}
+
+	if p.Lexer.PeekToken().IsType(T_LAMBDA) {
+		p.Lexer.EatToken()
+		if !isEnd() {
+			slice.Step = p.parseRequiredExpression()
+			if slice.Step == nil {
+				return nil
+			}
+		}
+	}
+
+	return slice
+}
+
+// Parses function parameters with optional type annotations, eg:
+// `a, b: Number, ...c: List`
+func (p *PipeParser) parseParameters() []ast.Node {
+	p.lambdaLock.Push(true)
+	defer p.lambdaLock.Pop()
+
+	params := []ast.Node{}
+	for {
+		if p.Lexer.HasErrors() || p.HasErrors() {
+			break
+		}
+
+		param := p.parseExpression(0)
+		if param == nil {
+			break
+		}
+
+		if p.Lexer.PeekToken().IsType(T_LAMBDA) {
+			p.Lexer.EatToken()
+			tp := p.parseTypeAnnotation()
+
+			switch param := param.(type) {
+			case *ast.Identifier:
+				param.Type = tp
+			case *ast.Spread:
+				if ident, ok := param.Target.(*ast.Identifier); ok {
+					ident.Type = tp
+				}
+			}
+		}
+		params = append(params, param)
+
+		if !p.Lexer.PeekToken().IsType(T_COMMA) {
+			break
+		}
+		p.skipEoes()
+		p.Lexer.EatToken()
+	}
+
+	return params
+}
+
+// Parses a type annotation, eg: `Number` or `module.Type`
+func (p *PipeParser) parseTypeAnnotation() ast.Node {
+	cur := p.Lexer.PeekToken()
+	if !cur.IsType(T_IDENTIFIER) {
+		p.RegisterErrorWithToken(fmt.Sprintf("expected type annotation, received %s instead", escapeError(cur.Literal)), cur)
+		return nil
+	}
+
+	p.Lexer.EatToken()
+	var tp ast.Node = &ast.Identifier{Token: cur, Value: cur.Literal}
+	for p.Lexer.PeekToken().IsType(T_ACCESS) && p.Lexer.PeekTokenAt(1).IsType(T_IDENTIFIER) {
+		access := p.Lexer.EatToken()
+		right := p.Lexer.EatToken()
+		tp = &ast.Access{
+			Token: access,
+			Left:  tp,
+			Right: &ast.Identifier{Token: right, Value: right.Literal},
+		}
+	}
+
+	return tp
+}
+
 // ----------------------------------------------------------------------------
 // Postfix functions
 // ----------------------------------------------------------------------------
@@ -1210,6 +1495,26 @@ func (p *PipeParser) skipEoes() {
 	}
 }
 
+// Checks if the next tokens are a type annotation followed by an assignment,
+// like the `: Number :=` in `a: Number := 2`.
+func (p *PipeParser) isTypedDeclaration() bool {
+	if !p.Lexer.PeekToken().IsType(T_LAMBDA) {
+		return false
+	}
+
+	tp := p.Lexer.PeekTokenAt(1)
+	if !tp.IsType(T_IDENTIFIER) || !unicode.IsUpper([]rune(tp.Literal)[0]) {
+		return false
+	}
+
+	i := 2
+	for p.Lexer.PeekTokenAt(i).IsType(T_ACCESS) && p.Lexer.PeekTokenAt(i+1).IsType(T_IDENTIFIER) {
+		i += 2
+	}
+
+	return p.Lexer.PeekTokenAt(i).IsType(T_ASSIGNMENT) && p.Lexer.PeekTokenAt(i).IsOneOfLiterals(":=", "=")
+}
+
 // Checks if the next token is the given types.
 func (p *PipeParser) ExpectTypes(expected ...tokens.TokenType) bool {
 	cur := p.Lexer.PeekToken()
@@ -3,6 +3,8 @@ package internal
 import (
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/renatopp/langtools/lexers"
 	"github.com/renatopp/langtools/runes"
@@ -64,6 +66,10 @@ func (p *PreLexer) tokenizer() *tokens.Token {
 			}
 			return tokens.NewToken(T_EOE, s1).WithRangeChars(c0, c1)
 
+		// raw strings
+		case c0.Is('r') && c1.IsOneOf('"', '\''):
+			return p.eatRawString().WithType(T_STRING)
+
 		case runes.IsAlpha(c0.Rune) || c0.Is('_'): // || c0.Is('$') || c0.Is('@'):
 			token := p.EatIdentifierWith('_') //, '$', '@')
 
@@ -104,9 +110,9 @@ func (p *PreLexer) tokenizer() *tokens.Token {
 		case c0.IsOneOf('"', '\''):
 			return p.EatString().WithType(T_STRING)
 
-		// raw strings
+		// multiline strings
 		case c0.Is('`'):
-			return p.EatRawString().WithType(T_STRING)
+			return p.eatMultilineString().WithType(T_STRING)
 
 			// spread
 		case s3 == "...":
@@ -223,3 +229,73 @@ func (p *PreLexer) EatChars(n int) []tokens.Char {
 	}
 	return result
 }
+
+// Consumes a string delimited by backticks, which may span several lines. The
+// escapes work like in the quoted strings, and \` escapes the backtick.
+func (p *PreLexer) eatMultilineString() *tokens.Token {
+	result := strings.Builder{}
+	first := p.EatChar()
+	for {
+		c := p.PeekChar()
+		if p.IsEof() {
+			p.RegisterErrorAt(lexers.ErrUnexpectedEndOfFile, c.Line, c.Column)
+			break
+		}
+		if c.Is('`') {
+			break
+		}
+		p.EatChar()
+
+		if !c.Is('\\') {
+			result.WriteRune(c.Rune)
+			continue
+		}
+
+		next := p.EatChar()
+		if next.Is('`') {
+			result.WriteRune('`')
+			continue
+		}
+		r, err := strconv.Unquote(`"\` + string(next.Rune) + `"`)
+		if err != nil {
+			p.RegisterErrorAt(err.Error(), next.Line, next.Column)
+			continue
+		}
+		result.WriteString(r)
+	}
+
+	p.EatChar()
+	return tokens.NewToken(tokens.UNKNOWN, result.String()).WithRangeChars(first, p.PeekChar())
+}
+
+// Consumes a raw string, like r'\d+' or r"it's". Unlike the quoted strings,
+// the backslashes are kept as they are, so patterns need no escaping. A quote
+// after a backslash does not end the string, but the backslash is kept too.
+func (p *PreLexer) eatRawString() *tokens.Token {
+	result := strings.Builder{}
+	first := p.EatChar()
+	quote := p.EatChar()
+	for {
+		c := p.PeekChar()
+		if c.Is('\n') {
+			p.RegisterErrorAt(lexers.ErrUnexpectedNewline, c.Line, c.Column)
+			p.EatChar()
+			continue
+		}
+		if p.IsEof() {
+			p.RegisterErrorAt(lexers.ErrUnexpectedEndOfFile, c.Line, c.Column)
+			break
+		}
+		if c.Is(quote.Rune) {
+			break
+		}
+		result.WriteRune(c.Rune)
+		p.EatChar()
+		if c.Is('\\') && p.PeekChar().Is(quote.Rune) {
+			result.WriteRune(p.EatChar().Rune)
+		}
+	}
+
+	p.EatChar()
+	return tokens.NewToken(tokens.UNKNOWN, result.String()).WithRangeChars(first, p.PeekChar())
+}
@@ -66,3 +66,26 @@ func TestNumbers(t *testing.T) {
 		assert.Equal(t, expected[i], token.Type)
 	}
 }
+
+func TestRawStrings(t *testing.T) {
+	lexer := internal.NewPreLexer([]byte(`r'\d+\n"' r"it's" r'a\'b' r 'x'`))
+	result := lexer.All()
+
+	assert.Equal(t, internal.T_STRING, result[0].Type)
+	assert.Equal(t, `\d+\n"`, result[0].Literal)
+	assert.Equal(t, "it's", result[1].Literal)
+	assert.Equal(t, `a\'b`, result[2].Literal)
+	assert.Equal(t, internal.T_IDENTIFIER, result[3].Type)
+	assert.Equal(t, "x", result[4].Literal)
+	assert.False(t, lexer.HasErrors())
+}
+
+func TestMultilineStrings(t *testing.T) {
+	lexer := internal.NewPreLexer([]byte("`a\\tb \\`c\\`\nd` 'a\\tb'"))
+	result := lexer.All()
+
+	assert.Equal(t, internal.T_STRING, result[0].Type)
+	assert.Equal(t, "a\tb `c`\nd", result[0].Literal)
+	assert.Equal(t, "a\tb", result[1].Literal)
+	assert.False(t, lexer.HasErrors())
+}
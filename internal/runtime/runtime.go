@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 	"slices"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/renatopp/pipelang/internal/ast"
 
 	"github.com/renatopp/pipelang/internal/builtins"
+	"github.com/renatopp/pipelang/internal/checker"
 	"github.com/renatopp/pipelang/internal/errfmt"
 	"github.com/renatopp/pipelang/internal/evaluator"
 	"github.com/renatopp/pipelang/internal/logs"
@@ -21,6 +23,7 @@ type Runtime struct {
 	objectCache map[string]o.Object
 	fileCache   *FileCache
 	loadStack   []string // file paths currently in execution, to detect circular imports
+	depth       int      // nested evaluations, like imports, which already hold the lock
 }
 
 func New() *Runtime {
@@ -38,6 +41,36 @@ func (r *Runtime) GlobalScope() *o.Scope {
 	return r.globalScope
 }
 
+// SetClock replaces the clock used by the time-aware functions.
+func (r *Runtime) SetClock(clock o.Clock) {
+	r.globalScope.WithClock(clock)
+}
+
+// SetStderr replaces the writer used for diagnostics, like progress bars.
+func (r *Runtime) SetStderr(w io.Writer) {
+	r.globalScope.WithStderr(w)
+}
+
+// TracePipes enables the tracing of the pipe calls, returning the tracer
+// collecting their metrics.
+func (r *Runtime) TracePipes() *o.PipeTracer {
+	tracer := o.NewPipeTracer()
+	r.globalScope.WithTracer(tracer)
+	return tracer
+}
+
+// SetArgs sets the command line arguments of the script, returned by
+// `Os.Args`.
+func (r *Runtime) SetArgs(args []string) {
+	r.globalScope.WithArgs(args)
+}
+
+// SetExit replaces the function called by `Os.Exit`, which is os.Exit by
+// default.
+func (r *Runtime) SetExit(exit func(code int)) {
+	r.globalScope.WithExit(exit)
+}
+
 func (r *Runtime) LoadAst(code []byte) (ast.Node, error) {
 	logs.Print("[runtime] running from code")
 
@@ -124,6 +157,32 @@ func (r *Runtime) RunFile(path string) (o.Object, error) {
 	return obj, nil
 }
 
+// CheckFile runs the type checker over a file, without running it.
+func (r *Runtime) CheckFile(path string) error {
+	logs.Print("[runtime] checking file (%s)", path)
+	path, err := r.getAbsolutePath(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := r.fileCache.Load(path)
+	if err != nil {
+		return err
+	}
+
+	errs := checker.Check(file.ast)
+	if len(errs) > 0 {
+		source, err := file.LoadSource()
+		if err != nil {
+			source = []byte{}
+		}
+
+		return errfmt.FormatCheckerErrors(errs, source, path)
+	}
+
+	return nil
+}
+
 func (r *Runtime) pushFileStack(path string) error {
 	if i := slices.Index(r.loadStack, path); i >= 0 {
 		return fmt.Errorf("circular import detected between '%s' and  '%s'", r.loadStack[i], path)
@@ -146,6 +205,13 @@ func (r *Runtime) getAbsolutePath(path string) (string, error) {
 }
 
 func (r *Runtime) evalAst(node ast.Node) (o.Object, *internal.Error) {
+	if r.depth == 0 {
+		r.globalScope.Acquire()
+		defer r.globalScope.Release()
+	}
+	r.depth++
+	defer func() { r.depth-- }()
+
 	eval := evaluator.New(r.globalScope)
 	obj, err := eval.Eval(node)
 	if err != nil {
@@ -2,6 +2,7 @@ package pipe
 
 import (
 	_ "embed"
+	"os"
 
 	"github.com/renatopp/pipelang/internal/runtime"
 )
@@ -29,6 +30,41 @@ func RunFile(path string) (string, error) {
 	return obj.AsString(), nil
 }
 
+// Options changes how a file is run.
+type Options struct {
+	// Args are the arguments of the script, returned by `Os.Args`.
+	Args []string
+
+	// TracePipes prints, to stderr, the elements and time of every pipe call
+	// once the file finishes.
+	TracePipes bool
+}
+
+func RunFileWithOptions(path string, opts Options) (string, error) {
+	rt := runtime.New()
+	rt.SetArgs(opts.Args)
+	if opts.TracePipes {
+		tracer := rt.TracePipes()
+		defer tracer.Report(os.Stderr)
+		rt.SetExit(func(code int) {
+			tracer.Report(os.Stderr)
+			os.Exit(code)
+		})
+	}
+
+	obj, err := rt.RunFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return obj.AsString(), nil
+}
+
+func CheckFile(path string) error {
+	rt := runtime.New()
+	return rt.CheckFile(path)
+}
+
 func Version() string {
 	return version
 }
@@ -2,7 +2,9 @@ package common
 
 import (
 	"testing"
+	"time"
 
+	"github.com/renatopp/pipelang/internal/checker"
 	"github.com/renatopp/pipelang/internal/object"
 	"github.com/renatopp/pipelang/internal/runtime"
 )
@@ -13,6 +15,16 @@ func Run(program string) (*object.Scope, object.Object, error) {
 	return r.GlobalScope(), obj, err
 }
 
+// RunWithClock runs the program with a fake clock, which only moves when the
+// program sleeps. It starts at the Unix epoch, in UTC.
+func RunWithClock(program string) (*object.FakeClock, object.Object, error) {
+	r := runtime.New()
+	clock := object.NewFakeClock(time.Unix(0, 0).UTC())
+	r.SetClock(clock)
+	obj, err := r.RunCode([]byte(program))
+	return clock, obj, err
+}
+
 func AssertCode(t *testing.T, input string, output string) {
 	_, obj, err := Run(input)
 	if err != nil {
@@ -25,9 +37,42 @@ func AssertCode(t *testing.T, input string, output string) {
 	}
 }
 
+func AssertTimedCode(t *testing.T, input string, output string) {
+	_, obj, err := RunWithClock(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if obj.AsString() != output {
+		t.Errorf("expected %s, got %s", output, obj.AsString())
+	}
+}
+
 func AssertCodeError(t *testing.T, input string) {
 	_, _, err := Run(input)
 	if err == nil {
 		t.Errorf("expected error, got nil")
 	}
 }
+
+func AssertCheck(t *testing.T, input string, errors ...string) {
+	r := runtime.New()
+	node, err := r.LoadAst([]byte(input))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	errs := checker.Check(node)
+	if len(errs) != len(errors) {
+		t.Errorf("expected %d type errors, got %d: %v", len(errors), len(errs), errs)
+		return
+	}
+
+	for i, e := range errs {
+		if e.Message != errors[i] {
+			t.Errorf("expected type error '%s', got '%s'", errors[i], e.Message)
+		}
+	}
+}
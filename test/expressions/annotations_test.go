@@ -0,0 +1,64 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestAnnotations_Assignment(t *testing.T) {
+	common.AssertCode(t, `x: Number := 3; x`, `3`)
+	common.AssertCode(t, `x: String := 'a'; x = 'b'; x`, `b`)
+	common.AssertCode(t, `x: Maybe := 3?; x.Ok()`, `true`)
+	common.AssertCodeError(t, `x: Number := 'a'`)
+	common.AssertCodeError(t, `x: Unknown := 'a'`)
+}
+
+func TestAnnotations_Function(t *testing.T) {
+	common.AssertCode(t, `fn add(a: Number, b: Number): Number { a + b }; add(1, 2)`, `3`)
+	common.AssertCode(t, `fn add(a: Number, b: Number) { a + b }; add('1', 2)?.Ok()`, `false`)
+	common.AssertCode(t, `fn f(...xs: List) { xs.Size() }; f(1, 2, 3)`, `3`)
+	common.AssertCode(t, `fn g(): Stream { yield 1 }; g() | sum`, `1`)
+	common.AssertCodeError(t, `fn f(): String { 1 }; f()`)
+	common.AssertCodeError(t, `fn f(x: String) { x }; f(1)`)
+	common.AssertCode(t, `f := fn (x) { x * 2 }; f(2)`, `4`)
+}
+
+func TestAnnotations_Data(t *testing.T) {
+	common.AssertCode(t, `data P { name: String = '' }; p := P { name = 'a' }; p.name`, `a`)
+	common.AssertCode(t, `data A { }; data B(A) { }; fn f(a: A) { 1 }; f(B {})`, `1`)
+	common.AssertCodeError(t, `data P { name: String = '' }; P { name = 1 }`)
+	common.AssertCodeError(t, `data P { name: String = '' }; P(1)`)
+}
+
+func TestAnnotations_Check(t *testing.T) {
+	common.AssertCheck(t, `x: Number := 1; y := x + 2`)
+	common.AssertCheck(t, `x: Number := 'a'`,
+		`cannot assign 'String' to 'x' of type 'Number'`)
+	common.AssertCheck(t, `x := 1; x = 'a'`,
+		`cannot assign 'String' to 'x' of type 'Number'`)
+	common.AssertCheck(t, `fn add(a: Number, b: Number): Number { a + b }; add('1', 2)`,
+		`expected parameter 'a' of 'add' to be of type 'Number', got 'String' instead`)
+	common.AssertCheck(t, `fn add(a: Number, b: Number) { a + b }; add(1)`,
+		`function 'add' expects 2 arguments, got 1`)
+	common.AssertCheck(t, `fn f(): String { return 1 }`,
+		`expected return value of type 'String', got 'Number' instead`)
+	common.AssertCheck(t, `fn f(): String { 1 }`,
+		`expected return value of type 'String', got 'Number' instead`)
+	common.AssertCheck(t, `fn f(): Number { yield 1 }`,
+		`generator function 'f' returns 'Stream', not 'Number'`)
+	common.AssertCheck(t, `data P { name: String = 3 }`,
+		`expected attribute 'name' to be of type 'String', got 'Number' instead`)
+	common.AssertCheck(t, `data P { name: String = '' }; P { name = 3 }`,
+		`expected attribute 'name' to be of type 'String', got 'Number' instead`)
+	common.AssertCheck(t, `x := 1 + 'a'`,
+		`types incompatible for operation 'Number' '+' 'String'`)
+	common.AssertCheck(t, `x: Number := [1, 2] | map(fn (x) { x })`,
+		`cannot assign 'Stream' to 'x' of type 'Number'`)
+	common.AssertCheck(t, `x: Number := [1, 2] | sum`)
+	common.AssertCheck(t, `data A { }; data B(A) { }; fn f(a: A) { 1 }; f(B {})`)
+	common.AssertCheck(t, `fn f(a: Time, b: Time): Duration { a - b }`)
+	common.AssertCheck(t, `fn f(a: Time, d: Duration): Time { a + d * 2 }`)
+	common.AssertCheck(t, `fn f(a: Time, b: Time): Time { a - b }`,
+		`expected return value of type 'Time', got 'Duration' instead`)
+}
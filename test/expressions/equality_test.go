@@ -0,0 +1,56 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestEquality(t *testing.T) {
+	common.AssertCode(t, `[1, 2] == [1, 2]`, `true`)
+	common.AssertCode(t, `[1, 2] == [1, 3]`, `false`)
+	common.AssertCode(t, `[1, 2] != [1, 2, 3]`, `true`)
+	common.AssertCode(t, `[[1], (2, 'a')] == [[1], (2, 'a')]`, `true`)
+	common.AssertCode(t, `(1, 'a') == (1, 'a')`, `true`)
+	common.AssertCode(t, `a := {a=1, b=[2]}; a == {b=[2], a=1}`, `true`)
+	common.AssertCode(t, `a := {a=1}; a == {a=2}`, `false`)
+	common.AssertCode(t, `Maybe(1) == Maybe(1)`, `true`)
+	common.AssertCode(t, `1 == 'a'`, `false`)
+}
+
+func TestEquality_Data(t *testing.T) {
+	common.AssertCode(t, `data P { x = 0 }; P { x=1 } == P { x=1 }`, `true`)
+	common.AssertCode(t, `data P { x = 0 }; P { x=1 } == P { x=2 }`, `false`)
+	common.AssertCode(t, `data P { x = 0 }; data Q { x = 0 }; P() == Q()`, `false`)
+	common.AssertCode(t, `
+	data P {
+		x = 0
+		fn Equals(this, other) { this.x % 10 == other.x % 10 }
+		fn Compare(this, other) { this.x <=> other.x }
+	}
+	(P { x=1 } == P { x=11 }, P { x=1 } < P { x=2 })
+	`, `(true, true)`)
+	common.AssertCode(t, `
+	data Meters {
+		v = 0
+		fn Equals(this, other) { other == this.v }
+	}
+	m := Meters { v=3 }
+	(m == 3, 3 == m, 4 != m, m != 4)
+	`, `(true, true, true, true)`)
+}
+
+func TestOrdering(t *testing.T) {
+	common.AssertCode(t, `[1, 2] < [1, 3]`, `true`)
+	common.AssertCode(t, `[1, 2] <=> [1]`, `1`)
+	common.AssertCode(t, `(1, 'b') >= (1, 'a')`, `true`)
+	common.AssertCode(t, `[3, 1, 2] == [1, 2, 3]`, `false`)
+	common.AssertCodeError(t, `a := {a=1}; a < {a=2}`)
+	common.AssertCodeError(t, `[1] < ['a']`)
+}
+
+func TestEquality_Match(t *testing.T) {
+	common.AssertCode(t, `match [1, 2] { [1, 2]: 'yes'; _: 'no' }`, `yes`)
+	common.AssertCode(t, `a := {a=1}; match a { (2, 1): 'no'; _: 'yes' }`, `yes`)
+	common.AssertCode(t, `match (1, [2]) { (1, [3]): 'no'; (1, [2]): 'yes'; _: 'no' }`, `yes`)
+}
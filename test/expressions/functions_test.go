@@ -59,3 +59,134 @@ func TestGenerators(t *testing.T) {
 	`
 	common.AssertCodeError(t, def)
 }
+
+func TestGenerators_Send(t *testing.T) {
+	def := `
+	fn Accumulator() {
+		total := 0
+		for {
+			x := yield total
+			total += x
+		}
+	}
+	s := Accumulator()
+	[s.Next().Result(), s.Send(5).Result(), s.Send(10).Result(), s.Send(0).Result()]
+	`
+	common.AssertCode(t, def, `[0, 5, 15, 15]`)
+
+	def = `
+	fn Echo() {
+		x := yield 'start'
+		yield x
+	}
+	s := Echo()
+	[s.Send(1).Result(), s.Send(2).Result()]
+	`
+	common.AssertCode(t, def, `['start', 2]`)
+}
+
+func TestGenerators_Resume(t *testing.T) {
+	def := `
+	calls := 0
+	fn inc() { calls += 1; 1 }
+	fn list(a, b, c) { [a, b, c] }
+	fn Gen() {
+		x := inc() + (yield 'a')
+		y := list(inc(), yield 'b', inc())
+		z := inc() > 0 and (yield 'c')
+		yield (x, y, z)
+	}
+	s := Gen()
+	s.Next()
+	s.Send(10)
+	s.Send(20)
+	[s.Send(true).Result(), calls]
+	`
+	common.AssertCode(t, def, `[(11, [1, 20, 1], true), 4]`)
+}
+
+func TestGenerators_Throw(t *testing.T) {
+	def := `
+	fn Safe() {
+		for {
+			r := (yield 'ok')?
+			if not r.Ok() { yield 'caught ' .. r.Error().Msg() }
+		}
+	}
+	s := Safe()
+	[s.Next().Result(), s.Throw('boom').Result(), s.Next().Result()]
+	`
+	common.AssertCode(t, def, `['ok', 'caught boom', 'ok']`)
+
+	def = `
+	fn Unsafe() {
+		yield 1
+		yield 2
+	}
+	s := Unsafe()
+	s.Next()
+	[s.Throw('bad')?.Ok(), s.Finished()]
+	`
+	common.AssertCode(t, def, `[false, true]`)
+}
+
+func TestGenerators_Delegate(t *testing.T) {
+	def := `
+	fn Inner() {
+		yield 1
+		yield 2
+	}
+	fn Outer() {
+		yield 0
+		yield from Inner()
+		yield from [3, 4]
+		yield 5
+	}
+	Outer() | sum
+	`
+	common.AssertCode(t, def, `15`)
+
+	def = `
+	fn Double() {
+		x := yield 1
+		for { x = yield x * 2 }
+	}
+	fn Outer() {
+		yield from Double()
+	}
+	s := Outer()
+	[s.Next().Result(), s.Send(5).Result(), s.Send(7).Result()]
+	`
+	common.AssertCode(t, def, `[1, 10, 14]`)
+
+	common.AssertCodeError(t, `yield 1`)
+}
+
+
+func TestDefer(t *testing.T) {
+	def := `
+	log := []
+	fn f() {
+		defer log.Push(1)
+		defer log.Push(2)
+		log.Push(0)
+		return 5
+	}
+	[f(), log]
+	`
+	common.AssertCode(t, def, `[5, [0, 2, 1]]`)
+
+	def = `
+	log := []
+	fn f() {
+		defer log.Push('cleanup')
+		raise 'error'
+	}
+	f()?
+	log
+	`
+	common.AssertCode(t, def, `['cleanup']`)
+
+	common.AssertCodeError(t, `defer println(1)`)
+	common.AssertCodeError(t, `fn f() { defer raise 'error'; 1 }; f()`)
+}
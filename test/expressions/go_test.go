@@ -0,0 +1,47 @@
+package expression_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestGo(t *testing.T) {
+	common.AssertCode(t, `t := go 1 + 2; t.Wait().Value()`, `3`)
+	common.AssertCode(t, `f := fn(x) { sleep(10); x * 2 }; t := go f(4); t.Wait().Value()`, `8`)
+	common.AssertCode(t, `t := go 1; t.Wait(); t.Done()`, `true`)
+	common.AssertCode(t, `t := go fn() { sleep(50) }(); t.Done()`, `false`)
+	common.AssertCode(t, `t := go fn() { sleep(50) }(); t.Result().Ok()`, `false`)
+	common.AssertCode(t, `t := go raise 'boom'; t.Wait().Error()`, `boom`)
+}
+
+func TestGo_Closures(t *testing.T) {
+	// Call arguments are evaluated when the task is created
+	common.AssertCode(t, `f := fn(x) { sleep(10); x }; x := 1; t := go f(x); x = 2; t.Wait().Value()`, `1`)
+	common.AssertCode(t, `c := 0; ts := range(5) | map (i: go fn() { c += i }()) | List; waitAll(ts); c`, `10`)
+}
+
+func TestGo_Runtimes(t *testing.T) {
+	// Each runtime has its own interpreter lock, so a busy runtime does not
+	// stop the others
+	done := make(chan struct{})
+	go func() {
+		common.Run(`t := Time.Now(); for Time.Since(t).Milliseconds() < 300 { 'busy'.Size() }`)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	start := time.Now()
+	common.AssertCode(t, `'free'.Size()`, `4`)
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected the runtime to run alongside the busy one, took %s", elapsed)
+	}
+	<-done
+}
+
+func TestGo_Check(t *testing.T) {
+	common.AssertCheck(t, `t: Task = go 1`)
+	common.AssertCheck(t, `t: Number = go 1`,
+		"cannot assign 'Task' to 't' of type 'Number'")
+}
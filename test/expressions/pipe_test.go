@@ -21,3 +21,24 @@ func TestPipes(t *testing.T) {
 	common.AssertCode(t, fun+`25 | sum 5 | mult 2`, `60`)
 	common.AssertCode(t, fun+`10 | mult 2 | sum 5`, `25`)
 }
+
+func TestPipes_Close(t *testing.T) {
+	gen := `
+	log := []
+	fn Numbers() {
+		defer log.Push('closed')
+		i := 0
+		for {
+			yield i
+			i += 1
+		}
+	}
+	`
+
+	common.AssertCode(t, gen+`for x in Numbers() { if x == 2 { break } }; log`, `['closed']`)
+	common.AssertCode(t, gen+`fn f() { for x in Numbers() { return x } }; f(); log`, `['closed']`)
+	common.AssertCode(t, gen+`s := Numbers(); s.Next(); s.Close(); [log, s.Finished(), s.Next().Ok()]`, `[['closed'], true, false]`)
+	common.AssertCode(t, gen+`(Numbers() | map(x: if x == 2 { raise 'error' } else { x }) | sum)?; log`, `['closed']`)
+	common.AssertCode(t, gen+`s := Numbers() | filter(x: x > 1) | map(x: x * 2); s.Next(); s.Close(); log`, `['closed']`)
+	common.AssertCode(t, gen+`fn Outer() { yield from Numbers() }; s := Outer(); s.Next(); s.Close(); log`, `['closed']`)
+}
@@ -0,0 +1,38 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestSelect(t *testing.T) {
+	common.AssertCode(t, `ch := Channel(1); ch.Send(2); select { ch.Receive() as x: x.Value() * 10 }`, `20`)
+	common.AssertCode(t, `a := Channel(); b := Channel(1); b.Send('b'); select { a.Receive(): 'a'; b.Receive() as x: x.Value() }`, `b`)
+	common.AssertCode(t, `ch := Channel(1); select { ch.Send(1): 'sent'; _: 'full' }`, `sent`)
+	common.AssertCode(t, `ch := Channel(1); ch.Send(1); select { ch.Send(1): 'sent'; _: 'full' }`, `full`)
+	common.AssertCode(t, `ch := Channel(); go fn() { sleep(5); ch.Send(1) }(); select { ch.Receive() as x: x.Value() }`, `1`)
+	common.AssertCode(t, `ch := Channel(); ch.Close(); select { ch.Receive() as x: x.Ok() }`, `false`)
+	common.AssertCode(t, `ch := Channel()
+select {
+  ch.Receive(): 'received'
+  after(10): { 'timeout' }
+}`, `timeout`)
+}
+
+func TestSelect_Timers(t *testing.T) {
+	// Timers are only chosen if no other case is ready
+	common.AssertCode(t, `ch := Channel(1); ch.Send(1); select { after(0): 'timeout'; ch.Receive(): 'received' }`, `received`)
+	common.AssertCode(t, `t := after(0); sleep(10); select { t: 'timeout'; _: 'default' }`, `default`)
+}
+
+func TestSelect_Errors(t *testing.T) {
+	common.AssertCodeError(t, `select { 1: 'a' }`)
+	common.AssertCodeError(t, `ch := Channel(); ch.Close(); select { ch.Send(1): 'a' }`)
+	common.AssertCodeError(t, `ch := Channel(); select { _ as x: 'a' }`)
+}
+
+func TestSelect_Name(t *testing.T) {
+	// `select` is still a valid name when not followed by a block
+	common.AssertCode(t, `select := 3; select + 1`, `4`)
+}
@@ -0,0 +1,52 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/renatopp/pipelang/test/common"
+)
+
+func TestSlice_List(t *testing.T) {
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[1:3]`, `[1, 2]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[:-1]`, `[0, 1, 2, 3, 4]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[::2]`, `[0, 2, 4]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[-2:]`, `[4, 5]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[::-1]`, `[5, 4, 3, 2, 1, 0]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[5:1:-2]`, `[5, 3]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[10:]`, `[]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; xs[:]`, `[0, 1, 2, 3, 4, 5]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3, 4, 5]; i := 2; xs[i:i+2]`, `[2, 3]`)
+	common.AssertCodeError(t, `xs := [0, 1, 2]; xs[::0]`)
+	common.AssertCodeError(t, `xs := [0, 1, 2]; xs['a':]`)
+}
+
+func TestSlice_String(t *testing.T) {
+	common.AssertCode(t, `s := 'hello'; s[-3:]`, `llo`)
+	common.AssertCode(t, `s := 'hello'; s[1:3]`, `el`)
+	common.AssertCode(t, `s := 'hello'; s[::-1]`, `olleh`)
+	common.AssertCode(t, `s := 'ação'; s[1:3]`, `çã`)
+}
+
+func TestSlice_Tuple(t *testing.T) {
+	common.AssertCode(t, `(1, 2, 3)[1:]`, `(2, 3)`)
+	common.AssertCode(t, `(1, 2, 3)[-1]`, `3`)
+	common.AssertCodeError(t, `(1, 2, 3)[3]`)
+}
+
+func TestSlice_Assign(t *testing.T) {
+	common.AssertCode(t, `xs := [0, 1, 2, 3]; xs[1:3] = [9, 9, 9]; xs`, `[0, 9, 9, 9, 3]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3]; xs[1:] = []; xs`, `[0]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3]; xs[:0] = [7]; xs`, `[7, 0, 1, 2, 3]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3]; xs[::2] = [7, 8]; xs`, `[7, 1, 8, 3]`)
+	common.AssertCode(t, `xs := [0, 1, 2, 3]; xs[-1] = 7; xs`, `[0, 1, 2, 7]`)
+	common.AssertCodeError(t, `xs := [0, 1, 2, 3]; xs[::2] = [7]`)
+	common.AssertCodeError(t, `xs := [0, 1, 2, 3]; xs[1:2] = 7`)
+}
+
+func TestSlice_LambdasInIndex(t *testing.T) {
+	common.AssertCode(t, `xs := [10, 20, 30]; xs[[1, 2] | map x: x - 1 | sum]`, `20`)
+	common.AssertCode(t, `d := {2=4}; d[([1, 2] | map x: x * 2 | first).Value()]`, `4`)
+	common.AssertCode(t, `xs := [10, 20, 30]; xs[(fn() { 1 })():]`, `[20, 30]`)
+	common.AssertCode(t, `xs := [10, 20, 30]; xs[[0, 1] | map x: x * 2 | sum:]`, `[30]`)
+	common.AssertCode(t, `xs := [10, 20, 30]; xs[[x: x + 1][0](0)]`, `20`)
+}